@@ -0,0 +1,48 @@
+package cmdrouter
+
+import (
+	"context"
+	"strings"
+)
+
+// WithStrictArgs makes arguments typed after an option without ArgHandler invalid input.
+// By default they are ignored.
+func WithStrictArgs(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.strictArgs = enable
+	}
+}
+
+// argsKey is the context key for the arguments typed after the selected option.
+type argsKey struct{}
+
+// withArgs returns the context carrying the arguments for ArgHandler.
+// It always sets the value, so arguments given to a group don't reach its options.
+func withArgs(ctx context.Context, args []string) context.Context {
+	return context.WithValue(ctx, argsKey{}, args)
+}
+
+// argsFrom returns the arguments typed after the selected option, nil if there are none.
+func argsFrom(ctx context.Context) []string {
+	args, _ := ctx.Value(argsKey{}).([]string)
+	return args
+}
+
+// parseArgs splits the input into a single option, given by its number or hotkey,
+// and the arguments typed after it, e.g. "3 foo bar". The arguments are kept in c.args
+// for the option's ArgHandler.
+func (c *CmdRouter) parseArgs(input string) ([]int, bool) {
+	fields := strings.Fields(input)
+	if len(fields) < 2 { //nolint:mnd // an option and at least one argument
+		return nil, false
+	}
+
+	number, ok := c.parseOption(fields[0])
+	if !ok || c.strictArgs && c.options[number-1].ArgHandler == nil {
+		return nil, false
+	}
+
+	c.args = fields[1:]
+
+	return []int{number}, true
+}
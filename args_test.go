@@ -0,0 +1,50 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestArgHandler(t *testing.T) {
+	var output bytes.Buffer
+
+	var got [][]string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1 foo bar\n1\n0\n"), &output),
+		WithOptions(Option{Name: "Delete", ArgHandler: func(_ context.Context, args []string) error {
+			got = append(got, args)
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if len(got) != 2 || !slices.Equal(got[0], []string{"foo", "bar"}) || got[1] != nil {
+		t.Errorf("args = %q, want [[foo bar] []]", got)
+	}
+}
+
+func TestStrictArgs(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithStrictArgs(true),
+		WithInputOutput(strings.NewReader("1 foo\n0\n"), &output),
+		WithOptions(Option{Name: "Status", Handler: func(context.Context) error {
+			runs++
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 0 || !strings.Contains(output.String(), "Invalid number. Try again.") {
+		t.Errorf("arguments for an option without ArgHandler should be rejected, ran %d times:\n%s", runs, output.String())
+	}
+}
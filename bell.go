@@ -0,0 +1,33 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithBellOnComplete rings the terminal bell after a command completes,
+// so that the user can switch back to the terminal after a long task.
+// The bell is written only if the output is a terminal, see also WithBellThreshold.
+func WithBellOnComplete(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.bell = enable
+	}
+}
+
+// WithBellThreshold makes the bell enabled by WithBellOnComplete ring only
+// after commands that ran for at least d.
+func WithBellThreshold(d time.Duration) Setting {
+	return func(c *CmdRouter) {
+		c.bellThreshold = d
+	}
+}
+
+// ringBell writes the bell character after a command that ran for the given time,
+// if the bell is enabled and the output is a terminal.
+func (c *CmdRouter) ringBell(option Option, elapsed time.Duration) {
+	if !c.bell || option.group != nil || elapsed < c.bellThreshold || !c.terminalFunc(c.out) {
+		return
+	}
+
+	_, _ = fmt.Fprint(c.out, "\a")
+}
@@ -0,0 +1,52 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBellOnComplete(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithBellOnComplete(true),
+		WithBellThreshold(20*time.Millisecond),
+		WithInputOutput(strings.NewReader("1\n2\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Fast", Handler: func(context.Context) error {
+				return nil
+			}},
+			Option{Name: "Slow", Handler: func(context.Context) error {
+				time.Sleep(30 * time.Millisecond)
+				return nil
+			}},
+		),
+	)
+	router.terminalFunc = func(any) bool { return true }
+
+	router.Run(t.Context())
+
+	if n := strings.Count(output.String(), "\a"); n != 1 {
+		t.Errorf("bell rang %d times, want once after the slow command:\n%q", n, output.String())
+	}
+}
+
+func TestBellOnCompleteFromSearch(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithBellOnComplete(true),
+		WithInputOutput(strings.NewReader("//deploy\n1\n0\n"), &output),
+	)
+	router.Group("Release", Option{Name: "Deploy", Handler: func(context.Context) error { return nil }})
+	router.terminalFunc = func(any) bool { return true }
+
+	router.Run(t.Context())
+
+	if n := strings.Count(output.String(), "\a"); n != 1 {
+		t.Errorf("bell rang %d times, want once after the command run from search:\n%q", n, output.String())
+	}
+}
@@ -0,0 +1,72 @@
+package cmdrouter
+
+import "context"
+
+// NewCheckbox returns an option for an on/off setting, rendered as "[x] Name" or "[ ] Name".
+// The state is read with get every time the menu is shown. Selecting the option flips
+// the state with set and shows the menu again:
+//
+//	verbose := cmdrouter.NewCheckbox("Verbose",
+//		func(context.Context) bool { return cfg.Verbose },
+//		func(_ context.Context, on bool) error { cfg.Verbose = on; return nil },
+//	)
+func NewCheckbox(name string, get func(ctx context.Context) bool, set func(ctx context.Context, on bool) error) Option {
+	return Option{
+		Name: name,
+		Handler: func(ctx context.Context) error {
+			return set(ctx, !get(ctx))
+		},
+		mark: func(ctx context.Context) string {
+			if get(ctx) {
+				return "[x]"
+			}
+
+			return "[ ]"
+		},
+	}
+}
+
+// NewRadioGroup returns options for a setting with mutually exclusive choices,
+// one per choice, rendered as "(•) Choice" for the current one and "( ) Choice" for others.
+// The current choice is read with current every time the menu is shown. Selecting an option
+// makes it current with set and shows the menu again. The options belong to the title
+// Category, so that WithAutoSections shows them under a header:
+//
+//	router.AddOptions(cmdrouter.NewRadioGroup("Theme", theme, setTheme, "Light", "Dark", "System")...)
+func NewRadioGroup(
+	title string,
+	current func(ctx context.Context) string,
+	set func(ctx context.Context, choice string) error,
+	choices ...string,
+) []Option {
+	options := make([]Option, 0, len(choices))
+
+	for _, choice := range choices {
+		options = append(options, Option{
+			Name:     choice,
+			Category: title,
+			Handler: func(ctx context.Context) error {
+				return set(ctx, choice)
+			},
+			mark: func(ctx context.Context) string {
+				if current(ctx) == choice {
+					return "(•)"
+				}
+
+				return "( )"
+			},
+		})
+	}
+
+	return options
+}
+
+// label returns the name of the option as shown in the menu,
+// prefixed with the state of checkbox and radio options.
+func (o Option) label(ctx context.Context) string {
+	if o.mark == nil {
+		return o.displayName(ctx)
+	}
+
+	return o.mark(ctx) + " " + o.displayName(ctx)
+}
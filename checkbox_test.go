@@ -0,0 +1,82 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckboxFlipsState(t *testing.T) {
+	enabled := false
+
+	var flips []bool
+
+	router := NewCmdRouter("Settings", NewCheckbox("Verbose",
+		func(context.Context) bool { return enabled },
+		func(_ context.Context, on bool) error {
+			enabled = on
+			flips = append(flips, on)
+
+			return nil
+		},
+	))
+
+	var output bytes.Buffer
+
+	router.SetInputOutput(strings.NewReader("1\n1\n0\n"), &output)
+	router.Run(t.Context())
+
+	if len(flips) != 2 || !flips[0] || flips[1] {
+		t.Fatalf("flips = %v, want [true false]", flips)
+	}
+
+	out := output.String()
+
+	unchecked := strings.Index(out, "[ ] Verbose")
+	checked := strings.Index(out, "[x] Verbose")
+	last := strings.LastIndex(out, "[ ] Verbose")
+
+	if unchecked < 0 || checked < unchecked || last < checked {
+		t.Errorf("menu should render unchecked, checked, then unchecked again, got:\n%s", out)
+	}
+}
+
+func TestRadioGroupMovesMarker(t *testing.T) {
+	theme := "Light"
+
+	var picked []string
+
+	router := NewCmdRouter("Settings", NewRadioGroup("Theme",
+		func(context.Context) string { return theme },
+		func(_ context.Context, choice string) error {
+			theme = choice
+			picked = append(picked, choice)
+
+			return nil
+		},
+		"Light", "Dark", "System",
+	)...)
+
+	var output bytes.Buffer
+
+	router.SetInputOutput(strings.NewReader("2\n0\n"), &output)
+	router.Run(t.Context())
+
+	if len(picked) != 1 || picked[0] != "Dark" {
+		t.Fatalf("picked = %v, want [Dark]", picked)
+	}
+
+	menus := strings.Split(output.String(), "Enter option number")
+	if len(menus) != 3 {
+		t.Fatalf("expected 2 prompts, got output:\n%s", output.String())
+	}
+
+	if !strings.Contains(menus[0], "(•) Light") || !strings.Contains(menus[0], "( ) Dark") {
+		t.Errorf("Light should be marked first:\n%s", menus[0])
+	}
+
+	if !strings.Contains(menus[1], "( ) Light") || !strings.Contains(menus[1], "(•) Dark") {
+		t.Errorf("Dark should be marked after the pick:\n%s", menus[1])
+	}
+}
@@ -0,0 +1,32 @@
+package cmdrouter
+
+import (
+	"context"
+	"time"
+)
+
+// WithClock sets the function the router and its groups read the current time from,
+// e.g. a fake clock making command durations, the ConfirmTimeout countdown text, rate limits
+// and history timestamps deterministic in tests. A nil function restores time.Now.
+// Timers, such as the timeout of ConfirmTimeout, the context deadline shown at the prompt
+// and WithSlowHandlerWarning, still use real time.
+func WithClock(now func() time.Time) Setting {
+	return func(c *CmdRouter) {
+		if now == nil {
+			now = time.Now
+		}
+
+		c.now = now
+	}
+}
+
+// Now returns the current time from the clock of the router running the option,
+// see WithClock, or time.Now outside of a router. Middlewares and handlers can use it
+// to stay deterministic under a fake clock.
+func Now(ctx context.Context) time.Time {
+	if router, ok := ctx.Value(routerKey{}).(*CmdRouter); ok {
+		return router.now()
+	}
+
+	return time.Now()
+}
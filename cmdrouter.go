@@ -1,13 +1,18 @@
 package cmdrouter
 
 import (
-	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // TablePrinter defines the interface for printing tabular data to the console.
@@ -15,18 +20,111 @@ type TablePrinter interface {
 	PrintTable(out io.Writer, headers []string, rows [][]any)
 }
 
+// WidthAwarePrinter is an optional interface that a TablePrinter can implement
+// to fit the table into the width of the terminal. A maxWidth <= 0 means unlimited.
+type WidthAwarePrinter interface {
+	PrintTableWidth(out io.Writer, headers []string, rows [][]any, maxWidth int)
+}
+
+// HighlightingPrinter is an optional interface that a TablePrinter can implement
+// to highlight a row of the menu, e.g. the last selected option. highlightRow is
+// the index of the row in rows, and maxWidth is as in WidthAwarePrinter.
+type HighlightingPrinter interface {
+	PrintTableHighlight(out io.Writer, headers []string, rows [][]any, highlightRow, maxWidth int)
+}
+
+// SeparatorPrinter is an optional interface that a TablePrinter can implement
+// to provide the glyph of separator lines matching its borders, e.g. "─".
+type SeparatorPrinter interface {
+	SeparatorGlyph() string
+}
+
 // Handler represents a function that processes a CLI command.
 type Handler func(ctx context.Context) error
 
+// ResultHandler represents a function that processes a CLI command and returns a result.
+// The result is returned to programmatic callers of CmdRouter.Exec and ignored by the menu.
+type ResultHandler func(ctx context.Context) (any, error)
+
+// ArgHandler represents a function that processes a CLI command with the arguments
+// typed after the option number or hotkey, e.g. ["42"] for "3 42".
+type ArgHandler func(ctx context.Context, args []string) error
+
+// ErrorHandler handles an error returned by a command, see Option.OnError.
+type ErrorHandler func(ctx context.Context, err error)
+
 // Middleware wraps a Handler with additional logic (e.g. logging, validation, metrics).
 // It takes a Handler and returns a new Handler with the middleware applied.
 type Middleware func(Handler) Handler
 
+// NavMiddleware is a middleware wrapping only the options that open groups, so that it runs
+// when the user enters a group, e.g. to check access to a whole subtree or to time a session in it.
+type NavMiddleware func(Handler) Handler
+
+// scopedMiddlewares are middlewares applied only to the options matching pred.
+type scopedMiddlewares struct {
+	pred        func(Option) bool
+	middlewares []Middleware
+}
+
+// applyMiddlewares wraps the handler with the given middlewares,
+// so that the first middleware is the outermost one.
+func applyMiddlewares(handler Handler, middlewares []Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
 // Option defines a CLI command with its name, execution logic, and optional middlewares.
 type Option struct {
-	Name        string       // Name of the operation (e.g. "login")
-	Handler     Handler      // Function that executes the operation
-	middlewares []Middleware // List of per-option middlewares
+	Name          string                           // Name of the operation (e.g. "login")
+	ID            string                           // Stable identifier for tracking, a slug of Name if empty, see OptionID
+	Handler       Handler                          // Function that executes the operation
+	ResultHandler ResultHandler                    // Alternative to Handler returning a result, only one of them can be set
+	ArgHandler    ArgHandler                       // Alternative to Handler receiving the arguments typed after the option number
+	NameFunc      func(ctx context.Context) string // Optional name shown in the menu instead of Name, evaluated on every render
+	Icon          string                           // Optional icon shown before the name (e.g. "🔒")
+	Description   string                           // Optional details shown by typing "?" and the option number
+	Category      string                           // Optional section of the menu the option is shown in, see WithAutoSections
+	Weight        int                              // Position of the option in the menu with WithSort(SortByWeight), lower first
+	Hotkey        string                           // Optional key selecting the option instead of its number (e.g. "l")
+	OnError       ErrorHandler                     // Optional handler of the option's errors, see Run
+	Timeout       time.Duration                    // Optional limit of the option's run time, zero means no limit
+	Confirm       string                           // Optional y/N question asked before running, see AssumeYes
+	ConfirmTyped  string                           // Optional text to type before running, see AssumeYesTyped
+	Once          bool                             // If true, the option runs successfully at most once per Run session
+	middlewares   []Middleware                     // List of per-option middlewares
+	group         *CmdRouter                       // Submenu opened by this option, nil for regular commands
+	values        map[any]any                      // Context values injected before the handler runs
+	mark          func(ctx context.Context) string // State shown before the name, see NewCheckbox and NewRadioGroup
+}
+
+// WithValue returns a copy of the option that injects the key/value pair into the context
+// before its middlewares and handler run. It allows reusing one handler for several options:
+//
+//	deleteUser := cmdrouter.Option{Name: "Delete User", Handler: deleteEntity}.WithValue(entityKey, "user")
+//	deleteGroup := cmdrouter.Option{Name: "Delete Group", Handler: deleteEntity}.WithValue(entityKey, "group")
+func (o Option) WithValue(key, val any) Option {
+	values := make(map[any]any, len(o.values)+1)
+	for k, v := range o.values {
+		values[k] = v
+	}
+	values[key] = val
+
+	o.values = values
+	return o
+}
+
+// displayName returns the name shown in the menu and matched by WithFuzzyMatch and WithPrefixMatch:
+// the result of NameFunc if it is set, Name otherwise. Paths, IDs and Exec still use Name.
+func (o Option) displayName(ctx context.Context) string {
+	if o.NameFunc != nil {
+		return o.NameFunc(ctx)
+	}
+
+	return o.Name
 }
 
 // AddMiddleware attaches a middlewares to this option.
@@ -37,26 +135,170 @@ func (o *Option) AddMiddlewares(m ...Middleware) {
 // Run executes the Option by wrapping its Handler with all attached middlewares in order,
 // and then invoking the resulting Handler with the provided context.
 // Middlewares are applied in the order they were added.
+// Values attached with WithValue are injected into the context first. If Timeout is set,
+// the context passed to the middlewares and the handler is canceled after it elapses;
+// a deadline set earlier, e.g. by a router middleware, still applies if it is sooner.
+// A non-nil error is wrapped with the option name. If OnError is set, it receives
+// errors other than ErrRepeat instead, and Run returns nil, so router middlewares
+// such as DefaultLoggerMiddleware don't handle the error again.
 func (o *Option) Run(ctx context.Context) error {
-	handler := o.Handler
-	for i := len(o.middlewares) - 1; i >= 0; i-- {
-		handler = o.middlewares[i](handler)
+	for key, val := range o.values {
+		ctx = context.WithValue(ctx, key, val) //nolint:fatcontext // values are added once per run
+	}
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	handler := applyMiddlewares(o.handle, o.middlewares)
+	if err := handler(ctx); err != nil {
+		err = fmt.Errorf("option %q: %w", o.Name, err)
+		if o.OnError != nil && !errors.Is(err, ErrRepeat) {
+			o.OnError(ctx, err)
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// handle calls Handler, ResultHandler or ArgHandler once the confirmations
+// of the option are accepted, unless it is a Once option done already. The result of ResultHandler is stored
+// for CmdRouter.Exec if it is waiting for it.
+func (o *Option) handle(ctx context.Context) error {
+	if o.ambiguous() {
+		return ErrAmbiguousHandler
+	}
+
+	if alreadyDone(ctx, o) {
+		return nil
+	}
+
+	if ok, err := o.confirm(ctx); !ok {
+		return err
+	}
+
+	err := o.call(ctx)
+	if err == nil {
+		markDone(ctx, o)
 	}
 
-	return handler(ctx)
+	return err
+}
+
+// call calls the handler of the option that is set.
+func (o *Option) call(ctx context.Context) error {
+	if o.ArgHandler != nil {
+		return o.ArgHandler(ctx, argsFrom(ctx))
+	}
+
+	if o.ResultHandler == nil {
+		return o.Handler(ctx)
+	}
+
+	result, err := o.ResultHandler(ctx)
+	if slot, ok := ctx.Value(resultKey{}).(*resultSlot); ok {
+		slot.value = result
+	}
+
+	return err
+}
+
+// ambiguous reports whether more than one handler of the option is set.
+func (o *Option) ambiguous() bool {
+	set := 0
+
+	for _, ok := range []bool{o.Handler != nil, o.ResultHandler != nil, o.ArgHandler != nil} {
+		if ok {
+			set++
+		}
+	}
+
+	return set > 1
 }
 
 // CmdRouter represents the main CLI router that handles user input and dispatches commands.
 type CmdRouter struct {
-	name         string       // Display name of the router or menu section.
-	options      []Option     // List of available command handlers in this router.
-	middlewares  []Middleware // Global middlewares applied before each handler runs.
-	tablePrinter TablePrinter // Table printer used for rendering CLI menus.
-	isGroup      bool         // Indicates whether this router is a subgroup (submenu).
-	path         string       // Full path of this router in the CLI hierarchy, e.g. "/auth/login".
-	pathShow     bool         // If true, the path is shown at the top of the menu.
-	in           io.Reader    // defaults to os.Stdin
-	out          io.Writer    // defaults to os.Stdout
+	name               string              // Display name of the router or menu section.
+	options            []Option            // List of available command handlers in this router.
+	middlewares        []Middleware        // Global middlewares applied before each handler runs.
+	middlewareNames    []string            // Names of the global middlewares, empty for unnamed ones.
+	scoped             []scopedMiddlewares // Middlewares applied only to options matching a predicate.
+	navMiddlewares     []NavMiddleware     // Middlewares applied only to the options opening groups.
+	tablePrinter       TablePrinter        // Table printer used for rendering CLI menus.
+	isGroup            bool                // Indicates whether this router is a subgroup (submenu).
+	path               string              // Full path of this router in the CLI hierarchy, e.g. "/auth/login".
+	pathShow           bool                // If true, the path is shown at the top of the menu.
+	pathShowSet        bool                // If true, pathShow was set by SetPathShow and is not changed by the parent router.
+	pathSeparator      bool                // If true, a separator line is printed between the path and the menu.
+	showExit           bool                // If true, the Exit/<-Back row is shown in the menu.
+	backIndex          BackIndexStyle      // How the Exit/<-Back row is selected.
+	backKey            string              // Custom key selecting the Exit/<-Back row, empty for the default.
+	backLabel          string              // Custom label of the Exit/<-Back row, empty for the default.
+	nameFirst          bool                // If true, the name column is shown before the "#" column.
+	shortcutColumn     bool                // If true, the menu shows a column with the hotkeys of the options.
+	fuzzyMatch         bool                // If true, options can be selected by fuzzy matching their names.
+	prefixMatch        bool                // If true, options can be selected by a unique prefix of their names.
+	recallKey          string              // Input running the last selected option again, empty if disabled.
+	pager              bool                // If true, the output of commands goes through $PAGER on terminals.
+	handlerIndent      int                 // Number of spaces command output printed to Out(ctx) is indented with.
+	maxWidth           int                 // Width the menu is clipped to, 0 to fit it into the terminal.
+	scrollOffset       int                 // Column from which the clipped menu is shown.
+	tableWidth         int                 // Width of the last clipped table.
+	autoSections       bool                // If true, options are grouped under headers by their category.
+	sortOrder          SortOrder           // Order in which the options are shown.
+	dryRun             bool                // If true, commands are reported instead of being executed.
+	echo               bool                // If true, the selected command is printed before it runs.
+	multiSelect        bool                // If true, several options can be selected at once, e.g. "1,3,5-7".
+	continueOnError    bool                // If true, the remaining selected options run after an error.
+	readline           bool                // If true, the prompt supports line editing with history on terminals.
+	instantKeys        bool                // If true, single keys selecting an option are acted on without Enter on terminals.
+	runOnceThenExit    bool                // If true, the root menu exits after the first executed command.
+	collapseSingletons bool                // If true, groups with a single option run it directly.
+	typeAhead          bool                // If true, a selection typed ahead is taken without showing the menu.
+	strictArgs         bool                // If true, arguments typed after options without ArgHandler are invalid input.
+	bell               bool                // If true, the terminal bell rings after a command completes, see WithBellOnComplete.
+	bellThreshold      time.Duration       // Minimum run time of a command that rings the bell.
+	showDuration       bool                // If true, the run time of commands is printed after them.
+	slowWarning        time.Duration       // Run time after which a command is reported as still running, zero to disable.
+	altScreen          bool                // If true, the root menu runs on the alternate screen of the terminal.
+	cancelOnInterrupt  bool                // If true, Ctrl-C cancels the running command instead of the program.
+	isolated           bool                // If true, every command runs in its own goroutine.
+	envSelection       string              // Environment variable holding the selection for the first prompt.
+	emptyMessage       string              // Message shown in place of the options of an empty menu.
+	subtitle           string              // Line shown between the path and the menu, not inherited by groups.
+	args               []string            // Arguments typed after the selected option, see parseArgs.
+	in                 io.Reader           // defaults to os.Stdin
+	out                io.Writer           // defaults to os.Stdout
+	input              *lineReader         // Line reader over in, shared with groups.
+	editor             *lineReader         // Prompt line editor, nil unless readline or instant keys are enabled on a terminal.
+	keyEditor          *lineEditor         // Key reader behind editor, used to set up instant keys.
+	history            *history            // Selection history, shared with groups.
+	store              *Store              // State shared by the commands, shared with groups.
+	recorder           io.Writer           // Receives the input lines if the session is recorded, nil otherwise.
+	skipRedraw         bool                // If true, the menu is not redrawn before the next prompt.
+	lastSelected       int                 // Number of the option selected last, 0 if none, highlighted in the menu.
+	lastRun            string              // ID of the option run last without an error, empty if none, see WithRecallKey.
+	events             io.Writer           // Receives JSON events if WithJSONEvents is set, nil otherwise.
+	logger             *slog.Logger        // Base logger of the options, nil for the default one.
+	goos               string              // Operating system external commands are picked for, see OpenExternal.
+
+	terminalFunc   func(stream any) bool                         // Reports whether the stream is a terminal.
+	widthFunc      func(out io.Writer) int                       // Returns the output width in columns, or 0 if unknown.
+	now            func() time.Time                              // Returns the current time, see WithClock.
+	interrupts     func() (<-chan os.Signal, func())             // Relays interrupts while a command runs.
+	startOpener    func(name string, args ...string) error       // Starts the opener of OpenExternal without waiting for it.
+	onEnter        func(ctx context.Context)                     // Called every time the router loop starts.
+	onLeave        func(ctx context.Context)                     // Called every time the router loop ends.
+	onBack         func(ctx context.Context) (bool, error)       // Can veto leaving a group with <-Back.
+	onExitConfirm  func(ctx context.Context) bool                // Can veto leaving the root menu with Exit.
+	onInvalidInput func(input string) string                     // Returns the message for invalid input at the prompt.
+	fallback       func(ctx context.Context, input string) error // Handler of input not selecting any option.
 }
 
 // NewCmdRouter creates a new command router with the given name and optional handlers.
@@ -69,8 +311,19 @@ func NewCmdRouter(name string, options ...Option) *CmdRouter {
 		isGroup:      false,
 		path:         constructPath(name),
 		pathShow:     false,
+		showExit:     true,
+		recallKey:    defaultRecallKey,
 		in:           os.Stdin,
 		out:          os.Stdout,
+		input:        newLineReader(os.Stdin),
+		history:      &history{},
+		store:        &Store{},
+		terminalFunc: isTerminal,
+		widthFunc:    terminalWidth,
+		now:          time.Now,
+		interrupts:   notifyInterrupts,
+		goos:         runtime.GOOS,
+		startOpener:  startOpener,
 	}
 }
 
@@ -121,6 +374,89 @@ func WithInputOutput(in io.Reader, out io.Writer) Setting {
 	}
 }
 
+// WithShowExit shows or hides the Exit/<-Back row in the menu.
+func WithShowExit(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.ShowExit(enable)
+	}
+}
+
+// WithDryRun enables or disables dry-run mode in the CmdRouter.
+func WithDryRun(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.DryRun(enable)
+	}
+}
+
+// WithMultiSelect enables or disables selecting several options at once in the CmdRouter.
+func WithMultiSelect(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.MultiSelect(enable)
+	}
+}
+
+// WithContinueOnError sets whether the remaining selected options run after an error.
+func WithContinueOnError(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.ContinueOnError(enable)
+	}
+}
+
+// WithRunOnceThenExit makes the root menu exit after the first executed command.
+// Opening groups doesn't count as a command, so the user can still navigate to it.
+func WithRunOnceThenExit(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.runOnceThenExit = enable
+	}
+}
+
+// WithPathSeparator enables or disables a separator line between the path and the menu
+// when the path is shown. It uses the glyph of the table printer if it implements SeparatorPrinter.
+func WithPathSeparator(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.pathSeparator = enable
+	}
+}
+
+// WithOnInvalidInput sets a hook returning the message printed for invalid input at the prompt
+// instead of "Invalid number. Try again.". An empty message prints nothing.
+func WithOnInvalidInput(hook func(input string) string) Setting {
+	return func(c *CmdRouter) {
+		c.onInvalidInput = hook
+	}
+}
+
+// WithEmptyMessage sets a message shown in the menu, e.g. "No items available",
+// while the router has no options. The Exit/<-Back row is still shown.
+func WithEmptyMessage(message string) Setting {
+	return func(c *CmdRouter) {
+		c.emptyMessage = message
+	}
+}
+
+// WithSubtitle sets a line shown between the path and the menu, e.g. "Select an action below".
+// It is not inherited by groups, which can set their own with GroupWithSettings.
+func WithSubtitle(subtitle string) Setting {
+	return func(c *CmdRouter) {
+		c.subtitle = subtitle
+	}
+}
+
+// WithNameFirst places the name column before the "#" column in the menu.
+func WithNameFirst(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.nameFirst = enable
+	}
+}
+
+// WithEchoSelection enables or disables printing the selected command before it runs,
+// e.g. "▶ Running: Login (> Main Menu > Login)".
+func WithEchoSelection(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.echo = enable
+	}
+}
+
 // Setup applies additional settings to an existing CmdRouter.
 func (c *CmdRouter) Setup(settings ...Setting) {
 	for _, setting := range settings {
@@ -131,14 +467,63 @@ func (c *CmdRouter) Setup(settings ...Setting) {
 // Group creates a submenu as a nested router and registers it as an option in the current router.
 func (c *CmdRouter) Group(name string, options ...Option) *CmdRouter {
 	group := &CmdRouter{
-		name:         name,
-		options:      options,
-		tablePrinter: c.tablePrinter,
-		isGroup:      true,
-		path:         c.path + constructPath(name),
-		pathShow:     c.pathShow,
-		in:           c.in,
-		out:          c.out,
+		name:               name,
+		options:            options,
+		tablePrinter:       c.tablePrinter,
+		isGroup:            true,
+		path:               c.path + constructPath(name),
+		pathShow:           c.pathShow,
+		pathSeparator:      c.pathSeparator,
+		showExit:           c.showExit,
+		backIndex:          c.backIndex,
+		backKey:            c.backKey,
+		backLabel:          c.backLabel,
+		nameFirst:          c.nameFirst,
+		shortcutColumn:     c.shortcutColumn,
+		fuzzyMatch:         c.fuzzyMatch,
+		prefixMatch:        c.prefixMatch,
+		recallKey:          c.recallKey,
+		pager:              c.pager,
+		handlerIndent:      c.handlerIndent,
+		typeAhead:          c.typeAhead,
+		strictArgs:         c.strictArgs,
+		bell:               c.bell,
+		bellThreshold:      c.bellThreshold,
+		showDuration:       c.showDuration,
+		slowWarning:        c.slowWarning,
+		collapseSingletons: c.collapseSingletons,
+		maxWidth:           c.maxWidth,
+		autoSections:       c.autoSections,
+		sortOrder:          c.sortOrder,
+		echo:               c.echo,
+		dryRun:             c.dryRun,
+		multiSelect:        c.multiSelect,
+		continueOnError:    c.continueOnError,
+		in:                 c.in,
+		out:                c.out,
+		input:              c.input,
+		editor:             c.editor,
+		keyEditor:          c.keyEditor,
+		instantKeys:        c.instantKeys,
+		readline:           c.readline,
+		history:            c.history,
+		store:              c.store,
+		recorder:           c.recorder,
+		events:             c.events,
+		logger:             c.logger,
+		terminalFunc:       c.terminalFunc,
+		widthFunc:          c.widthFunc,
+		now:                c.now,
+		onInvalidInput:     c.onInvalidInput,
+		fallback:           c.fallback,
+		cancelOnInterrupt:  c.cancelOnInterrupt,
+		isolated:           c.isolated,
+		envSelection:       c.envSelection,
+		emptyMessage:       c.emptyMessage,
+		interrupts:         c.interrupts,
+		goos:               c.goos,
+		startOpener:        c.startOpener,
+		navMiddlewares:     slices.Clone(c.navMiddlewares),
 	}
 
 	c.AddOptions(Option{
@@ -147,11 +532,21 @@ func (c *CmdRouter) Group(name string, options ...Option) *CmdRouter {
 			group.Run(ctx)
 			return nil
 		},
+		group: group,
 	})
 
 	return group
 }
 
+// GroupWithSettings creates a submenu like Group and applies the given settings to it,
+// so that it can differ from the parent router, e.g. with its own printer or path display.
+func (c *CmdRouter) GroupWithSettings(name string, settings []Setting, options ...Option) *CmdRouter {
+	group := c.Group(name, options...)
+	group.Setup(settings...)
+
+	return group
+}
+
 // SetTablePrinter sets the table printer for this router and all its groups.
 func (c *CmdRouter) SetTablePrinter(printer TablePrinter) {
 	c.tablePrinter = printer
@@ -160,102 +555,668 @@ func (c *CmdRouter) SetTablePrinter(printer TablePrinter) {
 // AddMiddlewares registers a global middlewares that will run before every option.
 func (c *CmdRouter) AddMiddlewares(m ...Middleware) {
 	c.middlewares = append(c.middlewares, m...)
+	c.middlewareNames = append(c.middlewareNames, make([]string, len(m))...)
+}
+
+// AddNamedMiddleware registers a global middleware under a name, so that it can be
+// removed later with RemoveMiddleware, e.g. to switch off tracing while debugging.
+// A middleware already registered under the name is replaced in place.
+func (c *CmdRouter) AddNamedMiddleware(name string, m Middleware) {
+	if i := slices.Index(c.middlewareNames, name); i >= 0 && name != "" {
+		c.middlewares[i] = m
+		return
+	}
+
+	c.middlewares = append(c.middlewares, m)
+	c.middlewareNames = append(c.middlewareNames, name)
+}
+
+// RemoveMiddleware removes the global middleware registered under the name with
+// AddNamedMiddleware. It reports whether there was such a middleware.
+// The change applies to the commands run afterwards.
+func (c *CmdRouter) RemoveMiddleware(name string) bool {
+	i := slices.Index(c.middlewareNames, name)
+	if i < 0 || name == "" {
+		return false
+	}
+
+	c.middlewares = slices.Delete(c.middlewares, i, i+1)
+	c.middlewareNames = slices.Delete(c.middlewareNames, i, i+1)
+
+	return true
+}
+
+// AddMiddlewareWhere registers middlewares that run only for the options matching the predicate,
+// e.g. options with a common name prefix. They run after the global middlewares
+// and before the option's own middlewares.
+func (c *CmdRouter) AddMiddlewareWhere(pred func(Option) bool, m ...Middleware) {
+	c.scoped = append(c.scoped, scopedMiddlewares{pred: pred, middlewares: m})
+}
+
+// AddNavMiddleware registers middlewares that run only when the user enters a group
+// of this router, or of groups created afterwards. They run after the global and scoped
+// middlewares and before the option's own middlewares.
+func (c *CmdRouter) AddNavMiddleware(m ...NavMiddleware) {
+	c.navMiddlewares = append(c.navMiddlewares, m...)
+}
+
+// middlewaresFor returns the router middlewares that apply to the option:
+// the global ones followed by the ones whose predicate matches the option,
+// and the navigation ones for options opening groups.
+func (c *CmdRouter) middlewaresFor(option Option) []Middleware {
+	middlewares := c.middlewares[:len(c.middlewares):len(c.middlewares)]
+	for _, scoped := range c.scoped {
+		if scoped.pred(option) {
+			middlewares = append(middlewares, scoped.middlewares...)
+		}
+	}
+
+	if option.group != nil {
+		for _, m := range c.navMiddlewares {
+			middlewares = append(middlewares, Middleware(m))
+		}
+	}
+
+	return middlewares
 }
 
 // AddOptions appends new options to the router.
 func (c *CmdRouter) AddOptions(options ...Option) {
 	c.options = append(c.options, options...)
+	c.sortOptions()
+}
+
+// RemoveOption removes the first option with the given name from the router,
+// e.g. from a handler of a dynamic menu. It reports whether there was such an option.
+// The options after it are renumbered when the menu is shown next.
+func (c *CmdRouter) RemoveOption(name string) bool {
+	i := slices.IndexFunc(c.options, func(o Option) bool { return o.Name == name })
+	if i < 0 {
+		return false
+	}
+
+	c.options = slices.Delete(c.options, i, i+1)
+
+	return true
 }
 
 // PathShow enables or disables path display for the current router and its groups.
 // When enabled, the path will be printed at the top of the menu.
+// Groups that set their own path display with SetPathShow keep it.
 func (c *CmdRouter) PathShow(enable bool) {
 	c.pathShow = enable
+
+	for _, option := range c.options {
+		if option.group != nil && !option.group.pathShowSet {
+			option.group.PathShow(enable)
+		}
+	}
+}
+
+// SetPathShow enables or disables path display for the group and its subgroups,
+// overriding the setting of the parent router: later changes of the parent
+// with PathShow don't apply to the group.
+func (c *CmdRouter) SetPathShow(enable bool) {
+	c.pathShowSet = true
+	c.PathShow(enable)
+}
+
+// ShowExit shows or hides the Exit/<-Back row for the current router and its groups.
+// When hidden, entering 0 still exits the menu, it is just not advertised.
+func (c *CmdRouter) ShowExit(enable bool) {
+	c.showExit = enable
+}
+
+// DryRun enables or disables dry-run mode for the current router and its groups.
+// In dry-run mode selecting a command only prints what would run, without calling
+// its handler or middlewares. Groups can still be opened to explore the menu.
+func (c *CmdRouter) DryRun(enable bool) {
+	c.dryRun = enable
+}
+
+// MultiSelect enables or disables multi-select mode for the current router and its groups.
+// In multi-select mode the input may contain comma or space separated numbers and ranges,
+// e.g. "1,3,5-7", and the selected options run one after another in that order.
+func (c *CmdRouter) MultiSelect(enable bool) {
+	c.multiSelect = enable
+}
+
+// ContinueOnError sets whether the remaining selected options run after one of them
+// returns an error in multi-select mode. By default the execution stops on the first error.
+func (c *CmdRouter) ContinueOnError(enable bool) {
+	c.continueOnError = enable
+}
+
+// SetOnEnter sets a hook called every time the menu is opened,
+// e.g. on each visit of a group. It is not inherited by groups.
+func (c *CmdRouter) SetOnEnter(hook func(ctx context.Context)) {
+	c.onEnter = hook
+}
+
+// SetOnLeave sets a hook called every time the menu is closed,
+// whether by Exit/<-Back, the end of input or the context being done.
+// It is not inherited by groups.
+func (c *CmdRouter) SetOnLeave(hook func(ctx context.Context)) {
+	c.onLeave = hook
 }
 
 func (c *CmdRouter) SetInputOutput(in io.Reader, out io.Writer) {
 	c.in = in
 	c.out = out
+	c.input = newLineReader(in)
+	c.setupEditor()
+	c.applyRecorder()
+}
+
+// SetTreeInputOutput sets the input and output streams for this router
+// and all its already created groups.
+func (c *CmdRouter) SetTreeInputOutput(in io.Reader, out io.Writer) {
+	c.SetInputOutput(in, out)
+	c.shareStreams()
+}
+
+// shareStreams makes all groups of the router use its input and output streams.
+func (c *CmdRouter) shareStreams() {
+	for _, option := range c.options {
+		if group := option.group; group != nil {
+			group.in, group.out = c.in, c.out
+			group.input, group.editor, group.keyEditor = c.input, c.editor, c.keyEditor
+			group.shareStreams()
+		}
+	}
 }
 
 // Run starts the main router loop: shows the menu, processes input, applies middlewares,
-// and dispatches to the selected handler.
+// and dispatches to the selected handlers. The loop ends when the user exits,
+// the input ends or the context is done.
 func (c *CmdRouter) Run(ctx context.Context) {
-	const exitNumber = 0
-	for {
-		optionNumber := c.getOptionNumber()
-		if optionNumber == exitNumber {
+	defer c.useAltScreen()()
+
+	ctx = withSession(ctx)
+
+	if c.onEnter != nil {
+		c.onEnter(ctx)
+	}
+
+	if c.onLeave != nil {
+		defer c.onLeave(ctx)
+	}
+
+	for ctx.Err() == nil {
+		optionNumbers, ok := c.getOptionNumbers(ctx)
+		if !ok {
 			break
 		}
 
-		handler := c.options[optionNumber-1].Run
-		for i := len(c.middlewares) - 1; i >= 0; i-- {
-			handler = c.middlewares[i](handler)
+		if len(optionNumbers) == 0 {
+			if c.confirmLeave(ctx) {
+				break
+			}
+
+			continue
 		}
 
-		_, _ = fmt.Fprintln(c.out)
-		_ = handler(ctx)
-		_, _ = fmt.Fprintln(c.out)
+		ranCommand := c.runSelection(withArgs(ctx, c.args), optionNumbers)
+		if ranCommand && c.runOnceThenExit && !c.isGroup {
+			break
+		}
 	}
 }
 
-// getOptionNumber displays the menu and reads the user's numeric selection from stdin.
-// It keeps prompting until the input is a valid option number.
-func (c *CmdRouter) getOptionNumber() int {
-	c.showPath()
-	c.showMenu()
+// runSelection runs the selected options in order and reports whether
+// any of them was a command rather than a group. The numbers are resolved to the IDs
+// of the options first, as an earlier command may remove or move some of them:
+// a moved option still runs, and one that no longer exists is reported
+// as invalid input and stops the run.
+func (c *CmdRouter) runSelection(ctx context.Context, optionNumbers []int) bool {
+	ranCommand := false
+	ids := c.selectedIDs(optionNumbers)
 
-	scanner := bufio.NewScanner(c.in)
+	for i, optionNumber := range optionNumbers {
+		optionNumber, ok := c.currentNumber(optionNumber, ids[i])
+		if !ok {
+			c.reportInvalidInput(strconv.Itoa(optionNumbers[i]))
+			break
+		}
 
-	for {
-		_, _ = fmt.Fprint(c.out, "Enter option number: ")
+		option := c.options[optionNumber-1]
+		ranCommand = ranCommand || option.group == nil
+		c.lastSelected = optionNumber
+
+		err := c.runOption(ctx, option)
+		if err == nil {
+			c.lastRun = option.id()
+		}
+
+		if err != nil && !c.continueOnError {
+			break
+		}
+	}
+
+	return ranCommand
+}
+
+// selectedIDs returns the IDs of the options with the given numbers,
+// an empty ID for numbers out of range.
+func (c *CmdRouter) selectedIDs(optionNumbers []int) []string {
+	ids := make([]string, len(optionNumbers))
+
+	for i, number := range optionNumbers {
+		if number >= 1 && number <= len(c.options) {
+			ids[i] = c.options[number-1].id()
+		}
+	}
+
+	return ids
+}
+
+// currentNumber returns the current number of the option selected with the given number
+// and ID, preferring the same position if several options share the ID.
+// It reports false if the option no longer exists.
+func (c *CmdRouter) currentNumber(number int, id string) (int, bool) {
+	if id == "" {
+		return 0, false
+	}
+
+	if number >= 1 && number <= len(c.options) && c.options[number-1].id() == id {
+		return number, true
+	}
+
+	i := slices.IndexFunc(c.options, func(o Option) bool { return o.id() == id })
+
+	return i + 1, i >= 0
+}
+
+// runOption runs the option with the router middlewares applied.
+func (c *CmdRouter) runOption(ctx context.Context, option Option) error {
+	if collapsed, ok := c.collapse(option); ok {
+		return c.Compose(collapsed)(ctx)
+	}
+
+	return c.run(ctx, c, option, c.optionPath(option.Name), c.Compose(option))
+}
+
+// run runs the option of the owner router, a group of c or c itself, reached by the path
+// with its handler composed with middlewares, as selected in the menu of c: it records
+// the selection, applies the features of c to commands and reports the result.
+// Both menu selections and search results are run with it.
+func (c *CmdRouter) run(ctx context.Context, owner *CmdRouter, option Option, path string, handler Handler) error {
+	if c.dryRun && option.group == nil {
+		c.reportDryRun(option.Name, path)
+		return nil
+	}
+
+	ctx = owner.withOption(ctx, option, path)
+	ctx = withRouter(ctx, owner)
+	c.recordHistory(option, path)
+	c.echoSelection(option, path)
+	c.emit(event{Type: "selected", Path: path, Name: option.Name, ID: option.id()})
+
+	if option.group == nil {
+		handler = c.commandHandler(handler)
+	}
+
+	start := c.now()
+	err := c.execute(ctx, handler)
+	c.ringBell(option, c.now().Sub(start))
+	c.emitResult(option, path, err)
+
+	return err
+}
+
+// commandHandler wraps the handler of a command, composed with its middlewares,
+// with the features of the router that apply to running commands: repeating on ErrRepeat,
+// interruption, isolation, run time, pager, indentation and the slow handler warning.
+func (c *CmdRouter) commandHandler(handler Handler) Handler {
+	return repeating(c.interruptible(c.isolate(c.timed(c.paged(c.indented(c.watched(handler)))))))
+}
+
+// Compose returns the option handler wrapped with all middlewares that apply to it
+// in this router: global, then scoped by predicate, then navigation ones for groups,
+// then the option's own ones.
+// It doesn't run the handler, which makes it useful for testing middleware chains.
+func (c *CmdRouter) Compose(option Option) Handler {
+	return applyMiddlewares(option.Run, c.middlewaresFor(option))
+}
+
+// echoSelection prints the command about to run if selection echo is enabled.
+func (c *CmdRouter) echoSelection(option Option, path string) {
+	if c.echo && option.group == nil {
+		_, _ = fmt.Fprintf(c.out, "▶ Running: %s (%s)\n", option.Name, path)
+	}
+}
 
-		if !scanner.Scan() {
-			if scanner.Err() != nil {
-				_, _ = fmt.Fprintln(c.out, "Input error. Try again.")
+// optionPath returns the full path of an option of this router, e.g. "> Main Menu > Login".
+func (c *CmdRouter) optionPath(name string) string {
+	return strings.TrimSpace(c.path + constructPath(name))
+}
+
+// reportDryRun prints the command that would be executed in dry-run mode.
+func (c *CmdRouter) reportDryRun(name, path string) {
+	_, _ = fmt.Fprintf(c.out, "\n[dry-run] would run: %s (%s)\n\n", name, path)
+}
+
+// execute runs the handler, separating its output from the menu with blank lines.
+// If the handler calls SuppressRedraw, the trailing blank line and the next redraw are skipped.
+func (c *CmdRouter) execute(ctx context.Context, handler Handler) error {
+	_, _ = fmt.Fprintln(c.out)
 
-				continue
+	redraw := &redrawSlot{}
+
+	err := handler(context.WithValue(ctx, redrawKey{}, redraw))
+	if errors.Is(err, ErrRateLimited) {
+		_, _ = fmt.Fprintln(c.out, "Too many requests. Try again later.")
+	}
+
+	if redraw.suppressed {
+		c.skipRedraw = true
+		return err
+	}
+
+	_, _ = fmt.Fprintln(c.out)
+
+	return err
+}
+
+// getOptionNumbers displays the menu and reads the user's selection from stdin.
+// It keeps prompting until the input is a valid option number (or a list of them
+// in multi-select mode). An empty result means that Exit/<-Back was selected.
+// It returns false if the input ended or the context is done.
+// Input starting with "//" searches the whole menu tree instead,
+// and "?" followed by an option number shows the details of the option.
+// If the context has a deadline, the remaining time is shown in the prompt.
+// With WithTypeAhead, a valid selection typed ahead is taken without showing the menu.
+// Input not selecting any option goes to the fallback handler, if it is set.
+// The recall key, "r" by default, selects the option selected last again.
+func (c *CmdRouter) getOptionNumbers(ctx context.Context) ([]int, bool) {
+	if numbers, ok := c.typeAheadSelection(ctx); ok {
+		return numbers, true
+	}
+
+	c.redraw(ctx)
+
+	if numbers, ok := c.selectionFromEnv(); ok {
+		return numbers, true
+	}
+
+	for {
+		c.showPrompt(ctx)
+
+		line, err := c.selectionInput().readLine(ctx)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				_, _ = fmt.Fprintln(c.out, "Input error.")
 			}
 
 			break
 		}
 
-		input := strings.TrimSpace(scanner.Text())
-		option, err := strconv.Atoi(input)
-		if err == nil && option >= 0 && option <= len(c.options) {
-			return option
+		input := strings.TrimSpace(line)
+		if query, ok := strings.CutPrefix(input, searchPrefix); ok {
+			c.runSearch(ctx, query)
+			c.redraw(ctx)
+
+			continue
+		}
+
+		if c.scroll(input) {
+			c.redraw(ctx)
+
+			continue
+		}
+
+		if number, ok := strings.CutPrefix(input, helpPrefix); ok && c.showHelp(number) {
+			c.redraw(ctx)
+
+			continue
+		}
+
+		if numbers, ok := c.parseSelection(input); ok {
+			return numbers, true
+		}
+
+		if c.isRecallInput(input) {
+			if numbers, ok := c.recall(); ok {
+				return numbers, true
+			}
+
+			c.redraw(ctx)
+
+			continue
 		}
 
-		_, _ = fmt.Fprintln(c.out, "Invalid number. Try again.")
+		if matches := c.prefixMatches(ctx, input); len(matches) == 1 {
+			return matches, true
+		} else if len(matches) > 1 {
+			_, _ = fmt.Fprintf(c.out, "Ambiguous prefix %q: %s.\n", input, c.optionList(ctx, matches))
+			continue
+		}
+
+		if matches := c.fuzzyMatches(ctx, input); len(matches) == 1 {
+			return matches, true
+		} else if len(matches) > 1 {
+			c.showShortlist(ctx, matches)
+			continue
+		}
+
+		if c.runFallback(ctx, input) {
+			c.redraw(ctx)
+			continue
+		}
+
+		c.reportInvalidInput(input)
 	}
 
-	return 0
+	return nil, false
+}
+
+// reportInvalidInput prints the message for invalid input at the prompt.
+func (c *CmdRouter) reportInvalidInput(input string) {
+	message := "Invalid number. Try again."
+	if c.onInvalidInput != nil {
+		message = c.onInvalidInput(input)
+	}
+
+	if message != "" {
+		_, _ = fmt.Fprintln(c.out, message)
+	}
+}
+
+// showPrompt asks the user for an option number.
+func (c *CmdRouter) showPrompt(ctx context.Context) {
+	if deadline, ok := ctx.Deadline(); ok {
+		left := max(time.Until(deadline).Round(time.Second), 0)
+		_, _ = fmt.Fprintf(c.out, "Enter option number (%s left): ", left)
+
+		return
+	}
+
+	_, _ = fmt.Fprint(c.out, "Enter option number: ")
+}
+
+// parseSelection converts the input into option numbers.
+// The Exit/<-Back input is only accepted on its own and results in an empty selection.
+// Arguments typed after a single option are kept in c.args, see parseArgs.
+func (c *CmdRouter) parseSelection(input string) ([]int, bool) {
+	c.args = nil
+
+	if c.isExitInput(input) {
+		return nil, true
+	}
+
+	if number, ok := c.parseOption(input); ok {
+		return []int{number}, true
+	}
+
+	if c.multiSelect {
+		if numbers, ok := c.parseMultiSelection(input); ok {
+			return numbers, true
+		}
+	}
+
+	return c.parseArgs(input)
+}
+
+// parseOption converts the input into the number of a single option,
+// given by its number or hotkey.
+func (c *CmdRouter) parseOption(input string) (int, bool) {
+	option, err := strconv.Atoi(input)
+	if err == nil && option > 0 && option <= len(c.options) {
+		return option, true
+	}
+
+	return c.hotkeyOption(input)
 }
 
 // showMenu prints the command list using the configured table printer.
-func (c *CmdRouter) showMenu() {
+func (c *CmdRouter) showMenu(ctx context.Context) {
 	headers := []string{"#", c.name}
+	if c.nameFirst {
+		headers[0], headers[1] = headers[1], headers[0]
+	}
+
 	rows := make([][]any, 0, len(c.options))
+	shortcuts := make([]string, 0, len(c.options))
+
+	iconWidth := c.iconWidth()
+	highlightRow := -1
 
 	for i := range c.options {
-		rows = append(rows, []any{i + 1, c.options[i].Name})
+		if header := c.sectionHeader(i); header != nil {
+			rows = append(rows, header)
+			shortcuts = append(shortcuts, "")
+		}
+
+		if i+1 == c.lastSelected {
+			highlightRow = len(rows)
+		}
+
+		rows = append(rows, c.menuRow(i+1, withIcon(c.options[i].Icon, c.options[i].label(ctx), iconWidth)))
+		shortcuts = append(shortcuts, c.options[i].Hotkey)
+	}
+
+	if len(c.options) == 0 && c.emptyMessage != "" {
+		rows = append(rows, c.menuRow("", c.emptyMessage))
+		shortcuts = append(shortcuts, "")
+	}
+
+	if c.showExit {
+		rows = append(rows, c.menuRow(c.exitCell(), withIcon("", c.exitLabel(), iconWidth)))
+		shortcuts = append(shortcuts, "")
 	}
 
-	if c.isGroup {
-		rows = append(rows, []any{0, "<-Back"})
-	} else {
-		rows = append(rows, []any{0, "Exit"})
+	if c.shortcutColumn {
+		headers, rows = withShortcutColumn(headers, rows, shortcuts)
 	}
 
-	c.tablePrinter.PrintTable(c.out, headers, rows)
+	if !c.terminalFunc(c.out) {
+		highlightRow = -1
+	}
+
+	c.printTable(headers, rows, highlightRow)
 	_, _ = fmt.Fprintln(c.out)
+
+	c.emitMenu()
+}
+
+// menuRow arranges the number and name cells of a menu row in the configured column order.
+func (c *CmdRouter) menuRow(number, name any) []any {
+	if c.nameFirst {
+		return []any{name, number}
+	}
+
+	return []any{number, name}
+}
+
+// iconWidth returns the display width of the widest option icon.
+func (c *CmdRouter) iconWidth() int {
+	width := 0
+	for i := range c.options {
+		width = max(width, displayWidth(c.options[i].Icon))
+	}
+
+	return width
+}
+
+// withIcon prefixes the name with the icon padded to iconWidth,
+// so names with and without icons line up. A zero iconWidth leaves the name as is.
+func withIcon(icon, name string, iconWidth int) string {
+	if iconWidth == 0 {
+		return name
+	}
+
+	return icon + strings.Repeat(" ", iconWidth-displayWidth(icon)) + " " + name
+}
+
+// printTable renders the table with the configured printer. If the printer supports it
+// and the output width is known, the table is fitted into the terminal width.
+// The row with the highlightRow index, if not negative, is highlighted by printers supporting it.
+// If the printer panics, the panic is logged and the table is printed with DefaultPrinter instead.
+// The table is rendered into a buffer first, so that nothing printed before the panic is kept.
+func (c *CmdRouter) printTable(headers []string, rows [][]any, highlightRow int) {
+	var table bytes.Buffer
+
+	if !c.renderTable(&table, headers, rows, highlightRow) {
+		table.Reset()
+		DefaultPrinter{}.PrintTable(&table, headers, rows)
+	}
+
+	_, _ = c.out.Write(table.Bytes())
+}
+
+// renderTable writes the table with the configured printer to out.
+// It reports false if the printer panicked, after logging the panic.
+func (c *CmdRouter) renderTable(out io.Writer, headers []string, rows [][]any, highlightRow int) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.routerLogger().Error("table printer", "panic", r)
+			ok = false
+		}
+	}()
+
+	if c.maxWidth > 0 {
+		c.printClipped(out, headers, rows)
+		return true
+	}
+
+	if printer, ok := c.tablePrinter.(HighlightingPrinter); ok && highlightRow >= 0 {
+		printer.PrintTableHighlight(out, headers, rows, highlightRow, c.widthFunc(c.out))
+		return true
+	}
+
+	if printer, ok := c.tablePrinter.(WidthAwarePrinter); ok {
+		printer.PrintTableWidth(out, headers, rows, c.widthFunc(c.out))
+		return true
+	}
+
+	c.tablePrinter.PrintTable(out, headers, rows)
+
+	return true
+}
+
+// showSubtitle prints the subtitle of the menu if it is set.
+func (c *CmdRouter) showSubtitle() {
+	if c.subtitle != "" {
+		_, _ = fmt.Fprintln(c.out, c.subtitle)
+	}
 }
 
 // showPath prints the current router path if path display is enabled.
 // Useful for nested groups to provide context on the user's location in the CLI hierarchy.
 func (c *CmdRouter) showPath() {
-	if c.pathShow {
-		_, _ = fmt.Fprintln(c.out, c.path)
+	if !c.pathShow {
+		return
+	}
+
+	_, _ = fmt.Fprintln(c.out, c.path)
+
+	if c.pathSeparator {
+		glyph := "-"
+		if printer, ok := c.tablePrinter.(SeparatorPrinter); ok {
+			glyph = printer.SeparatorGlyph()
+		}
+
+		_, _ = fmt.Fprintln(c.out, strings.Repeat(glyph, displayWidth(strings.TrimSpace(c.path))))
 	}
 }
 
@@ -3,9 +3,13 @@ package cmdrouter
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"log/slog"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestBasicRouter(t *testing.T) {
@@ -126,3 +130,612 @@ func TestCustomTablePrinter(t *testing.T) {
 		t.Error("Custom table printer was not called")
 	}
 }
+
+func TestMenuFitsTerminalWidth(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	const width = 24
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(Option{Name: "A very long option name that overflows"}),
+		WithInputOutput(strings.NewReader("0\n"), &output),
+	)
+	router.widthFunc = func(io.Writer) int { return width }
+
+	router.Run(ctx)
+
+	for _, line := range strings.Split(output.String(), "\n") {
+		if utf8.RuneCountInString(line) > width {
+			t.Errorf("line %q exceeds width %d", line, width)
+		}
+	}
+
+	if !strings.Contains(output.String(), "…") {
+		t.Error("Long option name was not truncated")
+	}
+}
+
+func TestOptionRunWrapsError(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+
+	opt := Option{
+		Name: "Login",
+		Handler: func(_ context.Context) error {
+			return errSentinel
+		},
+	}
+
+	err := opt.Run(t.Context())
+	if err == nil || err.Error() != `option "Login": sentinel` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if !errors.Is(err, errSentinel) {
+		t.Error("wrapped error does not match the sentinel")
+	}
+
+	opt.Handler = func(_ context.Context) error { return nil }
+	if err := opt.Run(t.Context()); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+}
+
+func TestHiddenExitRow(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(Option{Name: "Test Option"}),
+		WithShowExit(false),
+		WithInputOutput(strings.NewReader("0\n1\n"), &output),
+	)
+	group := router.Group("Group")
+
+	router.Run(ctx)
+
+	if strings.Contains(output.String(), "Exit") {
+		t.Error("Exit row is shown")
+	}
+
+	if !group.isGroup || group.showExit {
+		t.Error("Group did not inherit the hidden exit row")
+	}
+}
+
+func TestOptionWithValue(t *testing.T) {
+	type entityKey struct{}
+
+	var deleted []string
+
+	deleteEntity := func(ctx context.Context) error {
+		entity, _ := ctx.Value(entityKey{}).(string)
+		deleted = append(deleted, entity)
+		return nil
+	}
+
+	deleteUser := Option{Name: "Delete User", Handler: deleteEntity}.WithValue(entityKey{}, "user")
+	deleteGroup := deleteUser.WithValue(entityKey{}, "group")
+	deleteGroup.Name = "Delete Group"
+
+	_ = deleteUser.Run(t.Context())
+	_ = deleteGroup.Run(t.Context())
+
+	if strings.Join(deleted, ",") != "user,group" {
+		t.Errorf("unexpected injected values: %v", deleted)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := false
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithDryRun(true),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{
+		Name: "Delete",
+		Handler: func(_ context.Context) error {
+			executed = true
+			return nil
+		},
+	})
+
+	router.Run(ctx)
+
+	if executed {
+		t.Error("Handler was executed in dry-run mode")
+	}
+
+	if !strings.Contains(output.String(), "[dry-run] would run: Delete (> Menu > Delete)") {
+		t.Error("Dry-run line not printed")
+	}
+}
+
+func TestMiddlewareWhere(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	callOrder := []string{}
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				callOrder = append(callOrder, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	admin := Option{Name: "Admin: Users", Handler: func(_ context.Context) error {
+		callOrder = append(callOrder, "admin")
+		return nil
+	}}
+	admin.AddMiddlewares(record("local"))
+
+	profile := Option{Name: "Profile", Handler: func(_ context.Context) error {
+		callOrder = append(callOrder, "profile")
+		return nil
+	}}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(admin, profile),
+		WithMiddlewares(record("global")),
+		WithInputOutput(strings.NewReader("1\n2\n0\n"), &output),
+	)
+	router.AddMiddlewareWhere(func(o Option) bool {
+		return strings.HasPrefix(o.Name, "Admin")
+	}, record("where"))
+
+	router.Run(ctx)
+
+	expected := "global,where,local,admin,global,profile"
+	if strings.Join(callOrder, ",") != expected {
+		t.Errorf("expected call order %s, got %v", expected, callOrder)
+	}
+}
+
+func TestGroupEnterLeaveHooks(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	events := []string{}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n1\n0\n0\n"), &output),
+	)
+	group := router.Group("Group", Option{Name: "Leaf", Handler: func(_ context.Context) error {
+		events = append(events, "leaf")
+		return nil
+	}})
+	group.SetOnEnter(func(_ context.Context) { events = append(events, "enter") })
+	group.SetOnLeave(func(_ context.Context) { events = append(events, "leave") })
+
+	router.Run(ctx)
+
+	expected := "enter,leaf,leave,enter,leave"
+	if strings.Join(events, ",") != expected {
+		t.Errorf("expected events %s, got %v", expected, events)
+	}
+}
+
+func TestRunOnceThenExit(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithRunOnceThenExit(true),
+		WithInputOutput(strings.NewReader("1\n0\n2\n2\n"), &output),
+	)
+	router.Group("Group")
+	router.AddOptions(Option{Name: "Command", Handler: func(_ context.Context) error {
+		executed++
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if executed != 1 {
+		t.Errorf("expected a single execution, got %d", executed)
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 3 {
+		t.Errorf("expected 3 prompts, got %d", prompts)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	callOrder := []string{}
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				callOrder = append(callOrder, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	opt := Option{Name: "Test", Handler: func(_ context.Context) error {
+		callOrder = append(callOrder, "handler")
+		return nil
+	}}
+	opt.AddMiddlewares(record("local"))
+
+	router := NewCmdRouterWithSettings("Menu", WithMiddlewares(record("global")))
+
+	handler := router.Compose(opt)
+	if len(callOrder) != 0 {
+		t.Fatal("Compose executed the handler")
+	}
+
+	if err := handler(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(callOrder, ",") != "global,local,handler" {
+		t.Errorf("unexpected call order %v", callOrder)
+	}
+}
+
+func TestOptionIcons(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(
+			Option{Name: "Admin Panel", Icon: "🔒"},
+			Option{Name: "Profile"},
+		),
+		WithInputOutput(strings.NewReader("0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	for _, row := range []string{"| 1 | 🔒 Admin Panel |", "| 2 |    Profile     |", "| 0 |    Exit        |"} {
+		if !strings.Contains(output.String(), row) {
+			t.Errorf("row %q not found in:\n%s", row, output.String())
+		}
+	}
+}
+
+func TestNameFirst(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := false
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithNameFirst(true),
+		WithOptions(Option{Name: "Login", Handler: func(_ context.Context) error {
+			executed = true
+			return nil
+		}}),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	for _, row := range []string{"| Menu  | # |", "| Login | 1 |", "| Exit  | 0 |"} {
+		if !strings.Contains(output.String(), row) {
+			t.Errorf("row %q not found in:\n%s", row, output.String())
+		}
+	}
+
+	if !executed {
+		t.Error("Handler was not executed")
+	}
+}
+
+func TestEchoSelection(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithEchoSelection(true),
+		WithOptions(Option{Name: "Login", Handler: func(_ context.Context) error {
+			output.WriteString("Handler executed\n")
+			return nil
+		}}),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	echo := strings.Index(output.String(), "▶ Running: Login (> Menu > Login)")
+	handler := strings.Index(output.String(), "Handler executed")
+
+	if echo == -1 || echo > handler {
+		t.Errorf("echo line does not precede the handler output:\n%s", output.String())
+	}
+}
+
+func TestGroupWithSettings(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	printer := &dummyPrinter{}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n0\n0\n"), &output),
+	)
+	group := router.GroupWithSettings("Group", []Setting{WithTablePrinter(printer)})
+
+	router.Run(ctx)
+
+	if !printer.called {
+		t.Error("Group printer was not called")
+	}
+
+	if router.tablePrinter == group.tablePrinter {
+		t.Error("Group settings changed the parent router")
+	}
+
+	if menus := strings.Count(output.String(), "| # | Menu  |"); menus != 2 {
+		t.Errorf("expected the root menu to be rendered twice by the default printer, got %d", menus)
+	}
+}
+
+func TestPathSeparator(t *testing.T) {
+	ctx := t.Context()
+
+	run := func(settings ...Setting) string {
+		var output bytes.Buffer
+
+		router := NewCmdRouterWithSettings("Menu", append(settings,
+			WithInputOutput(strings.NewReader("0\n"), &output))...)
+		router.Run(ctx)
+
+		return output.String()
+	}
+
+	if output := run(WithPath(true), WithPathSeparator(true)); !strings.Contains(output, "> Menu \n------\n+---") {
+		t.Errorf("separator is not printed between the path and the menu:\n%s", output)
+	}
+
+	if output := run(WithPath(true)); strings.Contains(output, "------\n") {
+		t.Errorf("separator is printed when disabled:\n%s", output)
+	}
+
+	if output := run(WithPathSeparator(true)); strings.Contains(output, "------\n") {
+		t.Errorf("separator is printed without the path:\n%s", output)
+	}
+}
+
+func TestGroupPathShowOverride(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithPath(true),
+		WithInputOutput(strings.NewReader("1\n0\n2\n0\n0\n"), &output),
+	)
+	shallow := router.Group("Shallow")
+	shallow.SetPathShow(false)
+	router.Group("Deep")
+
+	router.PathShow(true)
+	router.Run(t.Context())
+
+	if strings.Contains(output.String(), "> Menu > Shallow") {
+		t.Errorf("path shown in the group that disabled it:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "> Menu > Deep") {
+		t.Errorf("path not shown in the group inheriting it:\n%s", output.String())
+	}
+}
+
+func TestSubtitle(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithPath(true),
+		WithSubtitle("Select an action below"),
+		WithInputOutput(strings.NewReader("1\n0\n0\n"), &output),
+	)
+	router.GroupWithSettings("Tools", []Setting{WithSubtitle("Developer tools")})
+
+	router.Run(t.Context())
+
+	if !strings.Contains(output.String(), "> Menu \nSelect an action below\n+---") {
+		t.Errorf("subtitle not shown between the path and the menu:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "> Menu > Tools \nDeveloper tools\n+---") {
+		t.Errorf("group subtitle not shown:\n%s", output.String())
+	}
+
+	if strings.Count(output.String(), "Select an action below") != 2 {
+		t.Errorf("subtitle should be shown by the root menu only:\n%s", output.String())
+	}
+}
+
+func TestStaleSelectionRejected(t *testing.T) {
+	var output bytes.Buffer
+
+	var calls []string
+
+	record := func(name string) Handler {
+		return func(context.Context) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMultiSelect(true),
+		WithInputOutput(strings.NewReader("1,2\n"), &output),
+	)
+	reset := func() {
+		router.options = nil
+		router.AddOptions(
+			Option{Name: "Cleanup", Handler: func(context.Context) error {
+				router.RemoveOption("Temp")
+				return nil
+			}},
+			Option{Name: "Temp", Handler: record("Temp")},
+			Option{Name: "Keep", Handler: record("Keep")},
+		)
+	}
+
+	reset()
+	router.Run(t.Context())
+
+	if len(calls) != 0 || !strings.Contains(output.String(), "Invalid number. Try again.") {
+		t.Errorf("removed option should be rejected instead of the one shifted into its place, ran %v:\n%s", calls, output.String())
+	}
+
+	reset()
+	router.SetInputOutput(strings.NewReader("1,3\n"), &output)
+	router.Run(t.Context())
+
+	if strings.Join(calls, ",") != "Keep" {
+		t.Errorf("moved option should still run, ran %v:\n%s", calls, output.String())
+	}
+}
+
+func TestOnInvalidInput(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var invalid []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOnInvalidInput(func(input string) string {
+			invalid = append(invalid, input)
+			if input == "quiet" {
+				return ""
+			}
+			return "No option " + input + ", pick from the list."
+		}),
+		WithInputOutput(strings.NewReader("7\nquiet\n0\n"), &output),
+	)
+	router.Group("Group")
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "No option 7, pick from the list.") {
+		t.Errorf("custom message not printed:\n%s", output.String())
+	}
+
+	if strings.Contains(output.String(), "Invalid number") || strings.Contains(output.String(), "No option quiet") {
+		t.Errorf("unexpected invalid input message:\n%s", output.String())
+	}
+
+	if strings.Join(invalid, ",") != "7,quiet" {
+		t.Errorf("unexpected invalid inputs: %v", invalid)
+	}
+}
+
+func TestEmptyMessage(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithEmptyMessage("No items available"),
+		WithInputOutput(strings.NewReader("0\n"), &output),
+	)
+
+	router.Run(t.Context())
+
+	expected := "+---+--------------------+\n" +
+		"| # | Menu               |\n" +
+		"+---+--------------------+\n" +
+		"|   | No items available |\n" +
+		"| 0 | Exit               |\n" +
+		"+---+--------------------+\n"
+
+	if !strings.Contains(output.String(), expected) {
+		t.Errorf("expected empty message in the menu, got:\n%s", output.String())
+	}
+}
+
+func TestOptionOnError(t *testing.T) {
+	var logs, output bytes.Buffer
+
+	SetDefaultLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetDefaultLogger(nil)
+
+	errDenied := errors.New("denied")
+
+	var handled error
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithDefaults(),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{
+		Name:    "Login",
+		Handler: func(_ context.Context) error { return errDenied },
+		OnError: func(_ context.Context, err error) { handled = err },
+	})
+
+	router.Run(t.Context())
+
+	if !errors.Is(handled, errDenied) {
+		t.Errorf("OnError did not receive the error, got %v", handled)
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("error handled by OnError was logged: %s", logs.String())
+	}
+}
+
+func TestOptionTimeout(t *testing.T) {
+	router := NewCmdRouter("Menu", Option{
+		Name:    "Slow",
+		Timeout: 10 * time.Millisecond,
+		Handler: func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		},
+	})
+
+	err := router.Invoke(t.Context(), "Slow")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestOptionNameFunc(t *testing.T) {
+	var output bytes.Buffer
+
+	project := "foo"
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithPrefixMatch(true),
+		WithInputOutput(strings.NewReader("1\nswitch project (current: bar)\n0\n"), &output),
+	)
+	router.AddOptions(Option{
+		Name: "Switch project",
+		NameFunc: func(_ context.Context) string {
+			return "Switch project (current: " + project + ")"
+		},
+		Handler: func(_ context.Context) error {
+			project = "bar"
+			runs++
+			return nil
+		},
+	})
+
+	router.Run(t.Context())
+
+	for _, name := range []string{"Switch project (current: foo)", "Switch project (current: bar)"} {
+		if !strings.Contains(output.String(), name) {
+			t.Errorf("%q not shown:\n%s", name, output.String())
+		}
+	}
+
+	if runs != 2 {
+		t.Errorf("rendered name not matched, %d runs:\n%s", runs, output.String())
+	}
+}
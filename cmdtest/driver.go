@@ -0,0 +1,78 @@
+// Package cmdtest provides a Driver for testing menus built with cmdrouter.
+package cmdtest
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hahaclassic/cmdrouter"
+)
+
+// Driver drives a router with scripted input and captures its output.
+//
+//	d := cmdtest.NewDriver(router)
+//	d.Select(1).Type("john").Select(0).Run(ctx)
+//	d.AssertContains(t, "Hello, john!")
+type Driver struct {
+	router *cmdrouter.CmdRouter
+	input  []string
+	output bytes.Buffer
+}
+
+// NewDriver creates a Driver for the router. The router and all its groups
+// read the scripted input and write to the Driver's output.
+func NewDriver(router *cmdrouter.CmdRouter) *Driver {
+	return &Driver{router: router}
+}
+
+// Select queues the selection of the option with the given number.
+func (d *Driver) Select(number int) *Driver {
+	return d.Type(strconv.Itoa(number))
+}
+
+// Type queues a line of input.
+func (d *Driver) Type(line string) *Driver {
+	d.input = append(d.input, line)
+	return d
+}
+
+// Run runs the router with the queued input until it exits or the input ends.
+// The queue is cleared afterwards, while the output is accumulated between runs.
+func (d *Driver) Run(ctx context.Context) *Driver {
+	var in strings.Builder
+	for _, line := range d.input {
+		in.WriteString(line + "\n")
+	}
+	d.input = nil
+
+	d.router.SetTreeInputOutput(strings.NewReader(in.String()), &d.output)
+	d.router.Run(ctx)
+
+	return d
+}
+
+// Output returns everything the router has written so far.
+func (d *Driver) Output() string {
+	return d.output.String()
+}
+
+// AssertContains reports an error if the output doesn't contain s.
+func (d *Driver) AssertContains(t testing.TB, s string) {
+	t.Helper()
+
+	if !strings.Contains(d.Output(), s) {
+		t.Errorf("output doesn't contain %q:\n%s", s, d.Output())
+	}
+}
+
+// AssertNotContains reports an error if the output contains s.
+func (d *Driver) AssertNotContains(t testing.TB, s string) {
+	t.Helper()
+
+	if strings.Contains(d.Output(), s) {
+		t.Errorf("output contains %q:\n%s", s, d.Output())
+	}
+}
@@ -0,0 +1,40 @@
+package cmdtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hahaclassic/cmdrouter"
+)
+
+func TestDriver(t *testing.T) {
+	var greeted string
+
+	router := cmdrouter.NewCmdRouter("Main Menu")
+	router.AddOptions(cmdrouter.Option{
+		Name: "Greet",
+		Handler: func(ctx context.Context) error {
+			name, err := router.ReadBlock(ctx, "Name: ", "")
+			greeted = name
+			return err
+		},
+	})
+	router.Group("Settings", cmdrouter.Option{
+		Name: "Reset",
+		Handler: func(_ context.Context) error {
+			return nil
+		},
+	})
+
+	d := NewDriver(router)
+	d.Select(1).Type("john").Type(".").Select(2).Select(0).Select(0).Run(t.Context())
+
+	d.AssertContains(t, "| 1 | Greet     |")
+	d.AssertContains(t, "Name: ")
+	d.AssertContains(t, "| 1 | Reset    |")
+	d.AssertNotContains(t, "Invalid number")
+
+	if greeted != "john" {
+		t.Errorf("expected the typed name, got %q", greeted)
+	}
+}
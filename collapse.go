@@ -0,0 +1,30 @@
+package cmdrouter
+
+import "context"
+
+// WithCollapseSingletons makes selecting a group with a single option run that option
+// directly instead of opening a one-item menu. It applies recursively, so a chain of
+// single-option groups leads straight to its command. The group's middlewares still
+// apply, but its menu hooks (SetOnEnter, SetOnLeave) are not called.
+func WithCollapseSingletons(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.collapseSingletons = enable
+	}
+}
+
+// collapse returns a copy of the group-entry option that runs the only option of its group
+// as if it had been selected in the group menu. It reports false if the option
+// doesn't open a single-option group or singletons are not collapsed.
+func (c *CmdRouter) collapse(option Option) (Option, bool) {
+	group := option.group
+	if !c.collapseSingletons || group == nil || len(group.options) != 1 {
+		return option, false
+	}
+
+	only := group.options[0]
+	option.Handler = func(ctx context.Context) error {
+		return group.runOption(ctx, only)
+	}
+
+	return option, true
+}
@@ -0,0 +1,46 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCollapseSingletons(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var calls []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithCollapseSingletons(true),
+		WithInputOutput(strings.NewReader("1\n2\n0\n0\n"), &output),
+	)
+	deploy := router.Group("Deploy")
+	deploy.AddMiddlewares(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			calls = append(calls, "deploy middleware")
+			return next(ctx)
+		}
+	})
+	deploy.Group("Production", Option{Name: "Run", Handler: func(_ context.Context) error {
+		calls = append(calls, "run")
+		return nil
+	}})
+	router.Group("Settings", Option{Name: "Reset"}, Option{Name: "Export"})
+
+	router.Run(ctx)
+
+	if strings.Join(calls, ",") != "deploy middleware,run" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+
+	if strings.Contains(output.String(), "| # | Deploy") || strings.Contains(output.String(), "| # | Production") {
+		t.Errorf("single-option group menu was shown:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "| # | Settings |") {
+		t.Errorf("group with several options was not opened:\n%s", output.String())
+	}
+}
@@ -0,0 +1,151 @@
+package cmdrouter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupportedShell is returned by GenerateCompletion for shells other than bash and zsh.
+var ErrUnsupportedShell = errors.New("unsupported shell")
+
+// completionLevel holds the names that can follow a path in the menu tree.
+type completionLevel struct {
+	path  string   // Names leading to the level joined with "/", empty for the root.
+	names []string // Names of the options at the level, in menu order.
+}
+
+// GenerateCompletion writes a completion script for the given shell ("bash" or "zsh")
+// that completes the names of groups and commands at each level of the menu tree
+// as command-line arguments of the running program, e.g. `app Developer "Debug Logs"`.
+// The script is registered for the base name of os.Args[0].
+func (c *CmdRouter) GenerateCompletion(shell string, w io.Writer) error {
+	program := filepath.Base(os.Args[0])
+	levels := c.completionLevels()
+
+	switch shell {
+	case "bash":
+		return writeBashCompletion(w, program, levels)
+	case "zsh":
+		return writeZshCompletion(w, program, levels)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedShell, shell)
+	}
+}
+
+// completionLevels returns the names available at every level of the menu tree
+// that leads to a command, in menu order.
+func (c *CmdRouter) completionLevels() []completionLevel {
+	var levels []completionLevel
+
+	index := map[string]int{}
+	seen := map[string]bool{}
+
+	c.Walk(func(path []string, _ Option) {
+		for i, name := range path {
+			prefix := strings.Join(path[:i], "/")
+
+			n, ok := index[prefix]
+			if !ok {
+				n = len(levels)
+				index[prefix] = n
+				levels = append(levels, completionLevel{path: prefix})
+			}
+
+			if key := prefix + "/" + name; !seen[key] {
+				seen[key] = true
+				levels[n].names = append(levels[n].names, name)
+			}
+		}
+	})
+
+	return levels
+}
+
+// writeBashCompletion writes a bash completion script.
+func writeBashCompletion(w io.Writer, program string, levels []completionLevel) error {
+	function := "_" + shellIdentifier(program) + "_complete"
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# bash completion for %s, generated by cmdrouter.\n", program)
+	fmt.Fprintf(&b, "%s() {\n", function)
+	b.WriteString("\tlocal cur=${COMP_WORDS[COMP_CWORD]//\\\\/}\n")
+	b.WriteString("\tlocal menu_path\n")
+	b.WriteString("\tmenu_path=$(IFS=/; echo \"${COMP_WORDS[*]:1:COMP_CWORD-1}\")\n")
+	b.WriteString("\tmenu_path=${menu_path//\\\\/}\n")
+	b.WriteString("\tlocal IFS=$'\\n'\n")
+	b.WriteString("\tlocal words\n")
+	b.WriteString("\tcase \"$menu_path\" in\n")
+
+	for _, level := range levels {
+		words := compgenEscaper.Replace(strings.Join(level.names, "\n"))
+		fmt.Fprintf(&b, "\t%s) words=%s ;;\n", shellQuote(level.path), shellQuote(words))
+	}
+
+	b.WriteString("\t*) return ;;\n")
+	b.WriteString("\tesac\n")
+	b.WriteString("\tCOMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("\t[ ${#COMPREPLY[@]} -gt 0 ] && COMPREPLY=($(printf '%q\\n' \"${COMPREPLY[@]}\"))\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", function, shellQuote(program))
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// writeZshCompletion writes a zsh completion script.
+func writeZshCompletion(w io.Writer, program string, levels []completionLevel) error {
+	function := "_" + shellIdentifier(program)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#compdef %s\n", program)
+	fmt.Fprintf(&b, "# zsh completion for %s, generated by cmdrouter.\n", program)
+	fmt.Fprintf(&b, "%s() {\n", function)
+	b.WriteString("\tlocal menu_path=${(j:/:)${(Q)words[2,CURRENT-1]}}\n")
+	b.WriteString("\tlocal -a names\n")
+	b.WriteString("\tcase $menu_path in\n")
+
+	for _, level := range levels {
+		quoted := make([]string, 0, len(level.names))
+		for _, name := range level.names {
+			quoted = append(quoted, shellQuote(name))
+		}
+
+		fmt.Fprintf(&b, "\t%s) names=(%s) ;;\n", shellQuote(level.path), strings.Join(quoted, " "))
+	}
+
+	b.WriteString("\t*) return 1 ;;\n")
+	b.WriteString("\tesac\n")
+	b.WriteString("\tcompadd -a names\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", function, shellQuote(program))
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// compgenEscaper escapes the characters that compgen -W would expand in its word list.
+var compgenEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, `$`, `\$`, "`", "\\`")
+
+// shellQuote quotes the string for use as a single word in bash or zsh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellIdentifier replaces the characters not allowed in shell function names with "_".
+func shellIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+
+		return '_'
+	}, s)
+}
@@ -0,0 +1,44 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCompletion(t *testing.T) {
+	router := NewCmdRouter("Menu", Option{Name: "Login"})
+	router.Group("Developer").Group("Debug Logs", Option{Name: "Backend logs"})
+
+	var bash bytes.Buffer
+	if err := router.GenerateCompletion("bash", &bash); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"'Login\nDeveloper'", "'Developer') words='Debug Logs'", "'Developer/Debug Logs') words='Backend logs'", "complete -F"} {
+		if !strings.Contains(bash.String(), s) {
+			t.Errorf("bash completion misses %q:\n%s", s, bash.String())
+		}
+	}
+
+	var again bytes.Buffer
+	_ = router.GenerateCompletion("bash", &again)
+
+	if again.String() != bash.String() {
+		t.Error("completion output is not deterministic")
+	}
+
+	var zsh bytes.Buffer
+	if err := router.GenerateCompletion("zsh", &zsh); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(zsh.String(), "'Developer') names=('Debug Logs')") {
+		t.Errorf("zsh completion misses the group level:\n%s", zsh.String())
+	}
+
+	if err := router.GenerateCompletion("fish", &zsh); !errors.Is(err, ErrUnsupportedShell) {
+		t.Errorf("expected ErrUnsupportedShell, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// assumeYesKey is the context key for the confirmations accepted without asking.
+type assumeYesKey struct{}
+
+// assumeYes is the level of confirmations accepted without asking.
+type assumeYes int
+
+const (
+	assumeNone    assumeYes = iota // Every confirmation is asked.
+	assumeConfirm                  // y/N questions are accepted.
+	assumeTyped                    // Typed challenges are accepted too.
+)
+
+// AssumeYes returns a context in which y/N confirmations, see Confirm, ConfirmTimeout
+// and Option.Confirm, are accepted without reading input, like the --yes flag of many tools,
+// e.g. for RunArgs or Invoke in scripts. Typed challenges are still asked, see AssumeYesTyped.
+func AssumeYes(ctx context.Context) context.Context {
+	return context.WithValue(ctx, assumeYesKey{}, max(assumedFrom(ctx), assumeConfirm))
+}
+
+// AssumeYesTyped returns a context in which all confirmations, including the typed challenges
+// of ConfirmTyped and Option.ConfirmTyped, are accepted without reading input.
+// It is separate from AssumeYes so that destructive commands aren't run by accident.
+func AssumeYesTyped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, assumeYesKey{}, assumeTyped)
+}
+
+// assumedFrom returns the level of confirmations accepted without asking in the context.
+func assumedFrom(ctx context.Context) assumeYes {
+	assumed, _ := ctx.Value(assumeYesKey{}).(assumeYes)
+	return assumed
+}
+
+// Confirm asks a yes/no question, e.g. "Delete all users?", and reports whether it was
+// answered with y/yes. An empty answer means no, and other answers ask again.
+// With AssumeYes it returns true without asking. At the end of input it returns false and io.EOF.
+func (c *CmdRouter) Confirm(ctx context.Context, prompt string) (bool, error) {
+	if assumedFrom(ctx) >= assumeConfirm {
+		return true, nil
+	}
+
+	for {
+		_, _ = fmt.Fprintf(c.out, "%s [y/N]: ", prompt)
+
+		line, err := c.input.readLine(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		default:
+			_, _ = fmt.Fprintln(c.out, "Please answer y or n.")
+		}
+	}
+}
+
+// ConfirmTyped asks the user to type the text, e.g. the name of the database to drop,
+// and reports whether it was typed exactly. With AssumeYesTyped it returns true without asking;
+// AssumeYes alone doesn't skip it. At the end of input it returns false and io.EOF.
+func (c *CmdRouter) ConfirmTyped(ctx context.Context, prompt, text string) (bool, error) {
+	if assumedFrom(ctx) >= assumeTyped {
+		return true, nil
+	}
+
+	_, _ = fmt.Fprintf(c.out, "%s Type %q to confirm: ", prompt, text)
+
+	line, err := c.input.readLine(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(line) == text, nil
+}
+
+// confirm asks the confirmations of the option and reports whether it can run.
+// Declined confirmations print "Canceled." and return no error. Outside of a router
+// they can only be accepted with AssumeYes or AssumeYesTyped, otherwise the error is ErrNoRouter.
+func (o *Option) confirm(ctx context.Context) (bool, error) {
+	needed := assumeNone
+	if o.Confirm != "" {
+		needed = assumeConfirm
+	}
+
+	if o.ConfirmTyped != "" {
+		needed = assumeTyped
+	}
+
+	if assumedFrom(ctx) >= needed {
+		return true, nil
+	}
+
+	router, ok := ctx.Value(routerKey{}).(*CmdRouter)
+	if !ok {
+		return false, ErrNoRouter
+	}
+
+	accepted := true
+
+	var err error
+
+	if o.Confirm != "" {
+		accepted, err = router.Confirm(ctx, o.Confirm)
+	}
+
+	if accepted && err == nil && o.ConfirmTyped != "" {
+		accepted, err = router.ConfirmTyped(ctx, o.Name+":", o.ConfirmTyped)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if !accepted {
+		_, _ = fmt.Fprintln(router.out, "Canceled.")
+	}
+
+	return accepted, nil
+}
+
+// ConfirmTimeout asks a yes/no question and returns def if it isn't answered within d,
+// e.g. for "proceed in 10s unless you object" steps of semi-automated flows.
+// The prompt shows the default and the time left, counting down on terminals:
+//
+//	Deploy to production? [Y/n] (10s):
+//
+// An empty answer also selects def, and other answers than y/yes or n/no ask again
+// until the time runs out. At the end of input it returns def and io.EOF,
+// and def and ctx.Err() if the context is done first. With AssumeYes it returns true
+// without asking.
+func (c *CmdRouter) ConfirmTimeout(ctx context.Context, prompt string, d time.Duration, def bool) (bool, error) {
+	choices := "[y/N]"
+	if def {
+		choices = "[Y/n]"
+	}
+
+	if assumedFrom(ctx) >= assumeConfirm {
+		return true, nil
+	}
+
+	deadline := time.Now().Add(d)
+	start := c.now()
+	live := c.terminalFunc(c.out)
+
+	for {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			_, _ = fmt.Fprintln(c.out)
+			return def, nil
+		}
+
+		if live {
+			_, _ = fmt.Fprint(c.out, "\r")
+			wait = min(wait, time.Second)
+		}
+
+		shown := min(max(d-c.now().Sub(start), 0), d)
+		_, _ = fmt.Fprintf(c.out, "%s %s (%s): ", prompt, choices, shown.Round(time.Second))
+
+		line, err := c.readLineWithin(ctx, wait)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			if !live {
+				_, _ = fmt.Fprintln(c.out)
+				return def, nil
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return def, err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		default:
+			_, _ = fmt.Fprintln(c.out, "Please answer y or n.")
+		}
+	}
+}
+
+// readLineWithin reads a line from the router input, giving up after the wait time
+// with context.DeadlineExceeded. The line is then returned by the next read.
+func (c *CmdRouter) readLineWithin(ctx context.Context, wait time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	return c.input.readLine(ctx)
+}
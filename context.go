@@ -0,0 +1,92 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+)
+
+// ErrNoRouter is returned when the context doesn't belong to a running option.
+var ErrNoRouter = errors.New("no router in context")
+
+// loggerKey is the context key for the option logger.
+type loggerKey struct{}
+
+// routerKey is the context key for the router the running option belongs to.
+type routerKey struct{}
+
+// defaultLogger is the logger set by SetDefaultLogger, nil for slog.Default().
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetDefaultLogger sets the logger used by all routers instead of slog.Default(),
+// e.g. to redirect the logs of DefaultLoggerMiddleware in tests.
+// A nil logger restores slog.Default().
+func SetDefaultLogger(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// WithSilentLogger makes the router and its groups discard the logs of their options,
+// including the ones of DefaultLoggerMiddleware, e.g. to keep test output clean.
+func WithSilentLogger() Setting {
+	return func(c *CmdRouter) {
+		c.logger = slog.New(slog.DiscardHandler)
+	}
+}
+
+// Logger returns the logger of the running option. It is based on the logger set by
+// SetDefaultLogger (slog.Default() if none) and includes the "option" name and "path"
+// attributes. Outside of an option it returns the default logger.
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return baseLogger()
+}
+
+// baseLogger returns the logger set by SetDefaultLogger, or slog.Default() if none.
+func baseLogger() *slog.Logger {
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// withOption returns a context carrying the ID of the option
+// and a logger enriched with the option name and path.
+func (c *CmdRouter) withOption(ctx context.Context, option Option, path string) context.Context {
+	ctx = context.WithValue(ctx, optionIDKey{}, option.id())
+
+	return context.WithValue(ctx, loggerKey{}, c.routerLogger().With("option", option.Name, "path", path))
+}
+
+// routerLogger returns the logger set for the router, or the default one.
+func (c *CmdRouter) routerLogger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+
+	return baseLogger()
+}
+
+// withRouter returns a context carrying the router the running option belongs to.
+func withRouter(ctx context.Context, router *CmdRouter) context.Context {
+	return context.WithValue(ctx, routerKey{}, router)
+}
+
+// AddOptionFromContext appends the option to the router the running option belongs to,
+// e.g. for an "Add bookmark" command. The new option appears when the menu is shown next.
+// It must be called from the handler goroutine, since the menu isn't shown while a handler runs.
+// Outside of an option it returns ErrNoRouter.
+func AddOptionFromContext(ctx context.Context, option Option) error {
+	router, ok := ctx.Value(routerKey{}).(*CmdRouter)
+	if !ok {
+		return ErrNoRouter
+	}
+
+	router.AddOptions(option)
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOptionLogger(t *testing.T) {
+	var logs, output bytes.Buffer
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Login", Handler: func(ctx context.Context) error {
+		Logger(ctx).Info("logged in")
+		return nil
+	}})
+
+	router.Run(t.Context())
+
+	if !strings.Contains(logs.String(), `msg="logged in" option=Login path="> Menu > Login"`) {
+		t.Errorf("log line misses the option context: %s", logs.String())
+	}
+}
+
+func TestAddOptionFromContext(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n"), &output),
+	)
+	group := router.Group("Bookmarks")
+	group.AddOptions(Option{Name: "Add bookmark", Handler: func(ctx context.Context) error {
+		return AddOptionFromContext(ctx, Option{Name: "Go docs"})
+	}})
+
+	router.Run(t.Context())
+
+	if len(group.options) != 2 || len(router.options) != 1 {
+		t.Fatalf("option was not added to the group: %d group options, %d root options",
+			len(group.options), len(router.options))
+	}
+
+	if !strings.Contains(output.String(), "| 2 | Go docs      |") {
+		t.Errorf("added option is not shown on the next render:\n%s", output.String())
+	}
+
+	if err := AddOptionFromContext(t.Context(), Option{Name: "Orphan"}); !errors.Is(err, ErrNoRouter) {
+		t.Errorf("expected ErrNoRouter, got %v", err)
+	}
+}
+
+func TestSilentLogger(t *testing.T) {
+	var logs, output bytes.Buffer
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithDefaults(),
+		WithSilentLogger(),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Fail", Handler: func(_ context.Context) error {
+		return errors.New("failed")
+	}})
+
+	router.Run(t.Context())
+
+	if logs.Len() != 0 {
+		t.Errorf("silent logger wrote logs: %s", logs.String())
+	}
+}
+
+func TestSetDefaultLogger(t *testing.T) {
+	var logs bytes.Buffer
+
+	SetDefaultLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetDefaultLogger(nil)
+
+	router := NewCmdRouterWithSettings("Menu", WithDefaults())
+	router.AddOptions(Option{Name: "Fail", Handler: func(_ context.Context) error {
+		return errors.New("failed")
+	}})
+
+	_ = router.Invoke(t.Context(), "Fail")
+
+	if !strings.Contains(logs.String(), `option=Fail`) {
+		t.Errorf("error was not logged to the default logger: %q", logs.String())
+	}
+}
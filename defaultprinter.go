@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"unicode/utf8"
 )
 
 // DefaultPrinter prints tables using simple ASCII box drawing.
@@ -16,23 +15,63 @@ import (
 //	| 2 | View Profile   |
 //	| 0 | Exit           |
 //	+---+----------------+
-type DefaultPrinter struct{}
+type DefaultPrinter struct {
+	// MinRows is the minimum number of data rows in the table.
+	// Shorter tables are padded with blank rows, so the table height stays stable.
+	MinRows int
+}
+
+// SeparatorGlyph implements the SeparatorPrinter interface.
+func (p DefaultPrinter) SeparatorGlyph() string {
+	return "-"
+}
 
 // PrintTable implements the TablePrinter interface.
 func (p DefaultPrinter) PrintTable(out io.Writer, headers []string, rows [][]any) {
+	p.PrintTableWidth(out, headers, rows, 0)
+}
+
+// PrintTableWidth implements the WidthAwarePrinter interface.
+// If the table is wider than maxWidth, the widest columns are shrunk first
+// and their cells are truncated with an ellipsis. A maxWidth <= 0 means unlimited.
+func (p DefaultPrinter) PrintTableWidth(out io.Writer, headers []string, rows [][]any, maxWidth int) {
+	p.PrintTableHighlight(out, headers, rows, -1, maxWidth)
+}
+
+// PrintTableHighlight implements the HighlightingPrinter interface.
+// The highlighted row is printed in inverse video.
+func (p DefaultPrinter) PrintTableHighlight(out io.Writer, headers []string, rows [][]any, highlightRow, maxWidth int) {
 	if len(headers) == 0 {
 		return
 	}
 
 	colWidths := p.computeColumnWidths(headers, rows)
+	if maxWidth > 0 {
+		p.fitColumnWidths(colWidths, maxWidth)
+	}
+
 	p.printBorder(out, colWidths)
 	p.printRow(out, colWidths, p.toAny(headers))
 	p.printBorder(out, colWidths)
 
-	for _, row := range rows {
+	for i, row := range rows {
+		if i == highlightRow {
+			_, _ = fmt.Fprintln(out, inverseVideo+p.formatRow(colWidths, row)+resetStyle)
+			continue
+		}
+
 		p.printRow(out, colWidths, row)
 	}
 
+	blankRow := make([]any, len(headers))
+	for i := range blankRow {
+		blankRow[i] = ""
+	}
+
+	for range p.MinRows - len(rows) {
+		p.printRow(out, colWidths, blankRow)
+	}
+
 	p.printBorder(out, colWidths)
 }
 
@@ -40,12 +79,12 @@ func (p DefaultPrinter) PrintTable(out io.Writer, headers []string, rows [][]any
 func (DefaultPrinter) computeColumnWidths(headers []string, rows [][]any) []int {
 	colWidths := make([]int, len(headers))
 	for i, h := range headers {
-		colWidths[i] = utf8.RuneCountInString(h)
+		colWidths[i] = displayWidth(h)
 	}
 
 	for _, row := range rows {
 		for i, cell := range row {
-			length := utf8.RuneCountInString(fmt.Sprint(cell))
+			length := displayWidth(fmt.Sprint(cell))
 			if length > colWidths[i] {
 				colWidths[i] = length
 			}
@@ -55,6 +94,34 @@ func (DefaultPrinter) computeColumnWidths(headers []string, rows [][]any) []int
 	return colWidths
 }
 
+// fitColumnWidths shrinks the widest columns one character at a time
+// until the whole table fits into maxWidth. Each column keeps at least one character,
+// so on very narrow terminals the table may still be wider than maxWidth.
+func (DefaultPrinter) fitColumnWidths(colWidths []int, maxWidth int) {
+	const cellPadding = 3 // "| " before and " " after each cell
+
+	total := 1 // closing "|"
+	for _, w := range colWidths {
+		total += w + cellPadding
+	}
+
+	for total > maxWidth {
+		widest := 0
+		for i, w := range colWidths {
+			if w > colWidths[widest] {
+				widest = i
+			}
+		}
+
+		if colWidths[widest] <= 1 {
+			return
+		}
+
+		colWidths[widest]--
+		total--
+	}
+}
+
 // printBorder prints the horizontal border line based on column widths.
 func (DefaultPrinter) printBorder(out io.Writer, colWidths []int) {
 	const offset = 2
@@ -70,12 +137,23 @@ func (DefaultPrinter) printBorder(out io.Writer, colWidths []int) {
 }
 
 // printRow prints a single row with given column widths.
-func (DefaultPrinter) printRow(out io.Writer, colWidths []int, row []any) {
+// Cells are padded by their display width, so wide characters stay aligned.
+func (p DefaultPrinter) printRow(out io.Writer, colWidths []int, row []any) {
+	_, _ = fmt.Fprintln(out, p.formatRow(colWidths, row))
+}
+
+// formatRow formats a single row without the trailing newline.
+func (DefaultPrinter) formatRow(colWidths []int, row []any) string {
+	var line strings.Builder
+
 	for i, cell := range row {
-		format := fmt.Sprintf("| %%-%dv ", colWidths[i])
-		_, _ = fmt.Fprintf(out, format, cell)
+		text := truncate(fmt.Sprint(cell), colWidths[i])
+		padding := strings.Repeat(" ", colWidths[i]-displayWidth(text))
+		_, _ = fmt.Fprintf(&line, "| %s%s ", text, padding)
 	}
-	_, _ = fmt.Fprintln(out, "|")
+	line.WriteByte('|')
+
+	return line.String()
 }
 
 // toAny converts []string to []any for uniform row printing.
@@ -86,3 +164,30 @@ func (DefaultPrinter) toAny(strs []string) []any {
 	}
 	return result
 }
+
+// truncate shortens s to at most width display columns, replacing the tail with an ellipsis.
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+
+	const ellipsis = "…"
+
+	var result strings.Builder
+
+	used := displayWidth(ellipsis)
+	for _, r := range s {
+		if used+runeWidth(r) > width {
+			break
+		}
+
+		used += runeWidth(r)
+		result.WriteRune(r)
+	}
+
+	if width >= displayWidth(ellipsis) {
+		result.WriteString(ellipsis)
+	}
+
+	return result.String()
+}
@@ -0,0 +1,147 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPrinterMinRows(t *testing.T) {
+	var output bytes.Buffer
+
+	printer := DefaultPrinter{MinRows: 5}
+	printer.PrintTable(&output, []string{"#", "Menu"}, [][]any{
+		{1, "Login"},
+		{2, "Profile"},
+	})
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+
+	const borders, header = 3, 1
+	if len(lines) != borders+header+5 {
+		t.Fatalf("expected %d lines, got %d:\n%s", borders+header+5, len(lines), output.String())
+	}
+
+	for _, line := range lines {
+		if len(line) != len(lines[0]) {
+			t.Errorf("misaligned line %q", line)
+		}
+	}
+
+	if lines[len(lines)-2] != "|   |         |" {
+		t.Errorf("unexpected padding row %q", lines[len(lines)-2])
+	}
+}
+
+func TestDefaultPrinterWideCharacters(t *testing.T) {
+	var output bytes.Buffer
+
+	DefaultPrinter{}.PrintTable(&output, []string{"#", "Menu"}, [][]any{
+		{1, "登录"},
+		{2, "🔒 Admin"},
+		{3, "Cafe\u0301"},
+		{4, "Login"},
+	})
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	for _, line := range lines {
+		if displayWidth(line) != displayWidth(lines[0]) {
+			t.Errorf("line %q is not aligned with the border %q", line, lines[0])
+		}
+	}
+}
+
+func TestTruncateWideCharacters(t *testing.T) {
+	if got := truncate("登录登录", 5); got != "登录…" {
+		t.Errorf("unexpected truncation %q", got)
+	}
+
+	if got := truncate("Login", 5); got != "Login" {
+		t.Errorf("unexpected truncation %q", got)
+	}
+}
+
+// highlightRecorder is a printer recording the highlighted rows it is asked to print.
+type highlightRecorder struct {
+	DefaultPrinter
+
+	highlights []int
+}
+
+func (p *highlightRecorder) PrintTableHighlight(out io.Writer, headers []string, rows [][]any, highlightRow, maxWidth int) {
+	p.highlights = append(p.highlights, highlightRow)
+	p.DefaultPrinter.PrintTableHighlight(out, headers, rows, highlightRow, maxWidth)
+}
+
+func TestHighlightLastSelected(t *testing.T) {
+	var output bytes.Buffer
+
+	printer := &highlightRecorder{}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithTablePrinter(printer),
+		WithInputOutput(strings.NewReader("2\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Login", Handler: func(context.Context) error { return nil }},
+			Option{Name: "Logout", Handler: func(context.Context) error { return nil }},
+		),
+	)
+	router.terminalFunc = func(any) bool { return true }
+
+	router.Run(t.Context())
+
+	if len(printer.highlights) != 1 || printer.highlights[0] != 1 {
+		t.Fatalf("highlights = %v, want [1]", printer.highlights)
+	}
+
+	if !strings.Contains(output.String(), inverseVideo+"| 2 | Logout |"+resetStyle+"\n") {
+		t.Errorf("selected row not highlighted:\n%q", output.String())
+	}
+}
+
+// panickingPrinter is a buggy printer panicking partway through every table.
+type panickingPrinter struct{}
+
+func (panickingPrinter) PrintTable(out io.Writer, _ []string, _ [][]any) {
+	_, _ = io.WriteString(out, "partial table\n")
+	panic("broken printer")
+}
+
+func TestPrinterPanicFallback(t *testing.T) {
+	var output, logs bytes.Buffer
+
+	SetDefaultLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetDefaultLogger(nil)
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithTablePrinter(panickingPrinter{}),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+		WithOptions(Option{Name: "Login", Handler: func(context.Context) error {
+			runs++
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Errorf("Login ran %d times, want 1", runs)
+	}
+
+	if n := strings.Count(output.String(), "| 1 | Login |"); n != 2 {
+		t.Errorf("fallback menu printed %d times, want 2:\n%s", n, output.String())
+	}
+
+	if strings.Contains(output.String(), "partial table") {
+		t.Errorf("output of the panicking printer kept:\n%s", output.String())
+	}
+
+	if !strings.Contains(logs.String(), "broken printer") {
+		t.Errorf("printer panic not logged:\n%s", logs.String())
+	}
+}
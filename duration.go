@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithShowDuration prints the run time of every command after its output, e.g. "(took 1.2s)",
+// for quick profiling in interactive use.
+func WithShowDuration(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.showDuration = enable
+	}
+}
+
+// timed wraps the command handler so that its run time is printed if it is enabled.
+func (c *CmdRouter) timed(handler Handler) Handler {
+	if !c.showDuration {
+		return handler
+	}
+
+	return func(ctx context.Context) error {
+		start := c.now()
+		err := handler(ctx)
+
+		_, _ = fmt.Fprintf(c.out, "(took %s)\n", formatDuration(c.now().Sub(start)))
+
+		return err
+	}
+}
+
+// formatDuration rounds the duration for display: to milliseconds below a second,
+// e.g. "350ms", and to tenths of a second above, e.g. "1.2s".
+func formatDuration(d time.Duration) string {
+	const tenth = 100 * time.Millisecond
+
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	return d.Round(tenth).String()
+}
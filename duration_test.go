@@ -0,0 +1,65 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShowDuration(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithShowDuration(true),
+		WithInputOutput(strings.NewReader("2\n0\n1\n0\n"), &output),
+		WithOptions(Option{Name: "Build", Handler: func(context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}}),
+	)
+	router.Group("Tools")
+
+	router.Run(t.Context())
+
+	took := regexp.MustCompile(`\n\(took \d+ms\)\n`)
+	if n := len(took.FindAllString(output.String(), -1)); n != 1 {
+		t.Errorf("duration printed %d times, want once:\n%s", n, output.String())
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		350*time.Millisecond + 400*time.Microsecond: "350ms",
+		1234 * time.Millisecond:                     "1.2s",
+		90 * time.Second:                            "1m30s",
+	} {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestShowDurationWithClock(t *testing.T) {
+	var output bytes.Buffer
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithShowDuration(true),
+		WithClock(func() time.Time {
+			now = now.Add(1500 * time.Millisecond)
+			return now
+		}),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+		WithOptions(Option{Name: "Build", Handler: func(context.Context) error { return nil }}),
+	)
+
+	router.Run(t.Context())
+
+	if !strings.Contains(output.String(), "\n(took 1.5s)\n") {
+		t.Errorf("duration not read from the clock:\n%s", output.String())
+	}
+}
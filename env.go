@@ -0,0 +1,49 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WithEnvSelection makes the first prompt take its selection from the environment
+// variable with the given name if it is set, e.g. for driving one step of a menu in CI.
+// The value is an option number or name. It is unset once consumed, so the following
+// prompts read the input as usual.
+func WithEnvSelection(name string) Setting {
+	return func(c *CmdRouter) {
+		c.envSelection = name
+	}
+}
+
+// selectionFromEnv consumes the selection from the environment variable, if it is set.
+// It reports false if there is no valid selection in the environment.
+func (c *CmdRouter) selectionFromEnv() ([]int, bool) {
+	if c.envSelection == "" {
+		return nil, false
+	}
+
+	value, ok := os.LookupEnv(c.envSelection)
+	if !ok {
+		return nil, false
+	}
+
+	_ = os.Unsetenv(c.envSelection)
+	value = strings.TrimSpace(value)
+
+	if numbers, ok := c.parseSelection(value); ok {
+		_, _ = fmt.Fprintf(c.out, "Selected %q from %s.\n", value, c.envSelection)
+		return numbers, true
+	}
+
+	for i, option := range c.options {
+		if strings.EqualFold(option.Name, value) {
+			_, _ = fmt.Fprintf(c.out, "Selected %q from %s.\n", value, c.envSelection)
+			return []int{i + 1}, true
+		}
+	}
+
+	_, _ = fmt.Fprintf(c.out, "Invalid selection %q in %s.\n", value, c.envSelection)
+
+	return nil, false
+}
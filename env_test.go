@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEnvSelection(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	const variable = "CMDROUTER_TEST_CHOICE"
+
+	t.Setenv(variable, "deploy")
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithEnvSelection(variable),
+		WithInputOutput(strings.NewReader(""), &output),
+	)
+	router.AddOptions(Option{Name: "Status"}, Option{Name: "Deploy", Handler: func(_ context.Context) error {
+		executed++
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if executed != 1 {
+		t.Errorf("expected the option from the environment to run once, got %d", executed)
+	}
+
+	if _, ok := os.LookupEnv(variable); ok {
+		t.Error("the environment variable was not consumed")
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 1 {
+		t.Errorf("expected a single interactive prompt after the selection, got %d", prompts)
+	}
+}
@@ -0,0 +1,72 @@
+package cmdrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WithJSONEvents makes the router write newline-delimited JSON events to w alongside
+// its regular output, e.g. for a GUI front-end that renders its own menu and writes
+// the selected numbers to the router input. The events are:
+//
+//	{"type":"menu","path":"> Main Menu","options":[{"number":1,"name":"Login"}],"exit":"0"}
+//	{"type":"selected","path":"> Main Menu > Login","name":"Login"}
+//	{"type":"error","path":"> Main Menu > Login","name":"Login","err":"..."}
+func WithJSONEvents(w io.Writer) Setting {
+	return func(c *CmdRouter) {
+		c.events = w
+	}
+}
+
+// event is a JSON event written by a router with WithJSONEvents.
+type event struct {
+	Type    string        `json:"type"`
+	Path    string        `json:"path"`
+	Name    string        `json:"name,omitempty"`
+	ID      string        `json:"id,omitempty"`
+	Options []eventOption `json:"options,omitempty"`
+	Exit    string        `json:"exit,omitempty"`
+	Err     string        `json:"err,omitempty"`
+}
+
+// eventOption is an option listed in a menu event.
+type eventOption struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+	ID     string `json:"id"`
+	Group  bool   `json:"group,omitempty"`
+}
+
+// emit writes the event if JSON events are enabled.
+func (c *CmdRouter) emit(e event) {
+	if c.events == nil {
+		return
+	}
+
+	encoder := json.NewEncoder(c.events)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(e)
+}
+
+// emitMenu writes the menu event listing the options of the router.
+func (c *CmdRouter) emitMenu() {
+	if c.events == nil {
+		return
+	}
+
+	options := make([]eventOption, 0, len(c.options))
+	for i, option := range c.options {
+		options = append(options, eventOption{Number: i + 1, Name: option.Name, ID: option.id(), Group: option.group != nil})
+	}
+
+	c.emit(event{Type: "menu", Path: strings.TrimSpace(c.path), Options: options, Exit: fmt.Sprint(c.exitCell())})
+}
+
+// emitResult writes the error event if the command failed.
+func (c *CmdRouter) emitResult(option Option, path string, err error) {
+	if err != nil {
+		c.emit(event{Type: "error", Path: path, Name: option.Name, ID: option.id(), Err: err.Error()})
+	}
+}
@@ -0,0 +1,44 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONEvents(t *testing.T) {
+	ctx := t.Context()
+	var output, events bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithJSONEvents(&events),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Login", Handler: func(_ context.Context) error {
+		return errors.New("denied")
+	}})
+
+	router.Run(ctx)
+
+	lines := strings.Split(strings.TrimSpace(events.String()), "\n")
+
+	var first event
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Type != "menu" || len(first.Options) != 1 || first.Options[0].Name != "Login" || first.Exit != "0" {
+		t.Errorf("unexpected first event: %s", lines[0])
+	}
+
+	expected := []string{
+		`{"type":"selected","path":"> Menu > Login","name":"Login","id":"login"}`,
+		`{"type":"error","path":"> Menu > Login","name":"Login","id":"login","err":"option \"Login\": denied"}`,
+	}
+	if len(lines) != 4 || lines[1] != expected[0] || lines[2] != expected[1] {
+		t.Errorf("unexpected events:\n%s", events.String())
+	}
+}
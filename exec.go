@@ -0,0 +1,70 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrAmbiguousHandler is returned when more than one of Handler, ResultHandler
+// and ArgHandler of an option is set.
+var ErrAmbiguousHandler = errors.New("more than one of Handler, ResultHandler and ArgHandler is set")
+
+// resultKey is the context key for the resultSlot of Exec.
+type resultKey struct{}
+
+// resultSlot receives the result of a ResultHandler.
+type resultSlot struct {
+	value any
+}
+
+// Exec runs the command found by the names of the groups leading to it and its own name,
+// e.g. Exec(ctx, "Developer", "Logs"), without reading any input. The command runs with
+// the same middlewares as if the user had navigated to it. Exec returns the result of
+// the command's ResultHandler, or nil for commands with a plain Handler.
+// If the path doesn't lead to a command, the error wraps ErrUnknownPath.
+func (c *CmdRouter) Exec(ctx context.Context, path ...string) (any, error) {
+	target, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return target.exec(ctx)
+}
+
+// exec runs the command with its middlewares and returns the result of its ResultHandler.
+func (t treeOption) exec(ctx context.Context) (any, error) {
+	slot := &resultSlot{}
+	ctx = context.WithValue(ctx, resultKey{}, slot)
+	ctx = t.router.withOption(ctx, t.option, t.fullPath())
+	ctx = withRouter(ctx, t.router)
+
+	err := t.handler()(ctx)
+
+	return slot.value, err
+}
+
+// Invoke runs the command like Exec, discarding its result.
+func (c *CmdRouter) Invoke(ctx context.Context, path ...string) error {
+	_, err := c.Exec(ctx, path...)
+	return err
+}
+
+// RunAll runs every command of this router with its middlewares, without reading any input,
+// e.g. for smoke tests. It returns the errors aligned with the router options;
+// options opening groups are skipped and have nil errors.
+func (c *CmdRouter) RunAll(ctx context.Context) []error {
+	errs := make([]error, len(c.options))
+
+	for i, option := range c.options {
+		if option.group != nil {
+			continue
+		}
+
+		path := c.optionPath(option.Name)
+		optionCtx := withRouter(c.withOption(ctx, option, path), c)
+
+		errs[i] = c.Compose(option)(optionCtx)
+	}
+
+	return errs
+}
@@ -0,0 +1,83 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExecReturnsResult(t *testing.T) {
+	ctx := t.Context()
+	calls := 0
+
+	router := NewCmdRouterWithSettings("Menu", WithMiddlewares(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			calls++
+			return next(ctx)
+		}
+	}))
+	router.Group("Users", Option{
+		Name: "Count",
+		ResultHandler: func(_ context.Context) (any, error) {
+			return 42, nil
+		},
+	})
+
+	result, err := router.Exec(ctx, "Users", "Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != 42 {
+		t.Errorf("expected 42, got %v", result)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the router middleware to run once, got %d", calls)
+	}
+}
+
+func TestExecErrors(t *testing.T) {
+	ctx := t.Context()
+	handler := func(_ context.Context) error { return nil }
+
+	router := NewCmdRouter("Menu", Option{
+		Name:          "Ambiguous",
+		Handler:       handler,
+		ResultHandler: func(_ context.Context) (any, error) { return nil, nil },
+	})
+	router.Group("Group", Option{Name: "Leaf", Handler: handler})
+
+	if _, err := router.Exec(ctx, "Ambiguous"); !errors.Is(err, ErrAmbiguousHandler) {
+		t.Errorf("expected ErrAmbiguousHandler, got %v", err)
+	}
+
+	for _, path := range [][]string{{}, {"Missing"}, {"Group"}, {"Ambiguous", "Leaf"}, {"Group", "Missing"}} {
+		if err := router.Invoke(ctx, path...); !errors.Is(err, ErrUnknownPath) {
+			t.Errorf("path %v: expected ErrUnknownPath, got %v", path, err)
+		}
+	}
+
+	if err := router.Invoke(ctx, "Group", "Leaf"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	router := NewCmdRouter("Menu",
+		Option{Name: "Healthy", Handler: func(_ context.Context) error { return nil }},
+		Option{Name: "Broken", Handler: func(_ context.Context) error { return errFailed }},
+	)
+	router.Group("Group", Option{Name: "Skipped", Handler: func(_ context.Context) error {
+		t.Error("command of a group was run")
+		return nil
+	}})
+
+	errs := router.RunAll(t.Context())
+
+	if len(errs) != 3 || errs[0] != nil || !errors.Is(errs[1], errFailed) || errs[2] != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
@@ -0,0 +1,145 @@
+package cmdrouter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// BackIndexStyle defines how the Exit/<-Back row is selected.
+type BackIndexStyle int
+
+const (
+	// ZeroFirst selects Exit/<-Back with 0 (default).
+	ZeroFirst BackIndexStyle = iota
+	// LastNumber selects Exit/<-Back with the number following the last option.
+	LastNumber
+	// KeyOnly selects Exit/<-Back with a letter key: "q" for Exit and "b" for <-Back.
+	KeyOnly
+)
+
+const (
+	exitKey = "q" // Key leaving the root menu in KeyOnly style.
+	backKey = "b" // Key leaving a group in KeyOnly style.
+)
+
+// WithBackIndex sets how the Exit/<-Back row is selected in the CmdRouter.
+func WithBackIndex(style BackIndexStyle) Setting {
+	return func(c *CmdRouter) {
+		c.SetBackIndex(style)
+	}
+}
+
+// SetBackIndex sets how the Exit/<-Back row is selected for the current router and its groups.
+func (c *CmdRouter) SetBackIndex(style BackIndexStyle) {
+	c.backIndex = style
+}
+
+// WithBackLabelAndKey sets the key and label of the Exit/<-Back row, e.g. "b" and "<-Back".
+// The key is shown in the "#" column and selects the row. The number of the row
+// (see WithBackIndex) is still accepted unless the KeyOnly style is used.
+// Empty values keep the defaults.
+func WithBackLabelAndKey(key, label string) Setting {
+	return func(c *CmdRouter) {
+		c.SetBackLabelAndKey(key, label)
+	}
+}
+
+// SetBackLabelAndKey sets the key and label of the Exit/<-Back row
+// for the current router and its groups.
+func (c *CmdRouter) SetBackLabelAndKey(key, label string) {
+	c.backKey = key
+	c.backLabel = label
+}
+
+// exitCell returns the value shown in the "#" column of the Exit/<-Back row.
+func (c *CmdRouter) exitCell() any {
+	if c.backKey != "" {
+		return c.backKey
+	}
+
+	return c.indexCell()
+}
+
+// indexCell returns the value selecting the Exit/<-Back row in the current back index style.
+func (c *CmdRouter) indexCell() any {
+	switch c.backIndex {
+	case LastNumber:
+		return len(c.options) + 1
+	case KeyOnly:
+		if c.isGroup {
+			return backKey
+		}
+		return exitKey
+	default:
+		return 0
+	}
+}
+
+// exitLabel returns the name of the Exit/<-Back row.
+func (c *CmdRouter) exitLabel() string {
+	if c.backLabel != "" {
+		return c.backLabel
+	}
+
+	if c.isGroup {
+		return "<-Back"
+	}
+
+	return "Exit"
+}
+
+// isExitInput reports whether the input selects the Exit/<-Back row.
+func (c *CmdRouter) isExitInput(input string) bool {
+	if c.backKey != "" {
+		if strings.EqualFold(input, c.backKey) {
+			return true
+		}
+
+		if c.backIndex == KeyOnly {
+			return false
+		}
+	}
+
+	switch cell := c.indexCell().(type) {
+	case int:
+		number, err := strconv.Atoi(input)
+		return err == nil && number == cell
+	case string:
+		return strings.EqualFold(input, cell)
+	default:
+		return false
+	}
+}
+
+// SetOnBack sets a hook called when <-Back is selected in a group. If it returns false,
+// the group stays open, e.g. to keep unsaved changes. A returned error is logged.
+// Unlike the hook set by SetOnLeave, it is not called when the input ends or the context is done.
+func (c *CmdRouter) SetOnBack(hook func(ctx context.Context) (allow bool, err error)) {
+	c.onBack = hook
+}
+
+// SetOnExitConfirm sets a hook called when Exit is selected in the root menu.
+// If it returns false, the menu is shown again instead of exiting, e.g. to keep unsaved work.
+// It is not called when the input ends or the context is done.
+func (c *CmdRouter) SetOnExitConfirm(hook func(ctx context.Context) (allow bool)) {
+	c.onExitConfirm = hook
+}
+
+// confirmLeave reports whether the menu can be left after Exit/<-Back was selected.
+func (c *CmdRouter) confirmLeave(ctx context.Context) bool {
+	if !c.isGroup {
+		return c.onExitConfirm == nil || c.onExitConfirm(ctx)
+	}
+
+	if c.onBack == nil {
+		return true
+	}
+
+	allow, err := c.onBack(ctx)
+	if err != nil {
+		Logger(ctx).Error("back", "path", strings.TrimSpace(c.path), "err", err)
+	}
+
+	return allow
+}
@@ -0,0 +1,133 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBackIndexLastNumber(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithBackIndex(LastNumber),
+		WithInputOutput(strings.NewReader("0\n1\n1\n2\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Option", Handler: func(_ context.Context) error {
+		executed++
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "| 2 | Exit   |") {
+		t.Errorf("Exit row is not numbered after the last option:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "Invalid number. Try again.") {
+		t.Error("0 should not exit with LastNumber style")
+	}
+
+	if executed != 2 {
+		t.Errorf("expected 2 handler calls before exit, got %d", executed)
+	}
+}
+
+func TestBackIndexKeyOnly(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithBackIndex(KeyOnly),
+		WithInputOutput(strings.NewReader("1\nB\nq\n"), &output),
+	)
+	router.Group("Group")
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "| b | <-Back |") || !strings.Contains(output.String(), "| q | Exit  |") {
+		t.Errorf("Exit/<-Back keys are not rendered:\n%s", output.String())
+	}
+}
+
+func TestGroupOnBack(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	backCalls := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n0\n0\n0\n"), &output),
+	)
+	group := router.Group("Editor")
+	group.SetOnBack(func(_ context.Context) (bool, error) {
+		backCalls++
+		return backCalls > 1, nil
+	})
+
+	router.Run(ctx)
+
+	if backCalls != 2 {
+		t.Errorf("expected 2 OnBack calls, got %d", backCalls)
+	}
+
+	if menus := strings.Count(output.String(), "| # | Editor |"); menus != 2 {
+		t.Errorf("expected the group menu to be shown twice, got %d", menus)
+	}
+
+	if menus := strings.Count(output.String(), "| # | Menu   |"); menus != 2 {
+		t.Errorf("expected the root menu to be shown twice, got %d", menus)
+	}
+}
+
+func TestOnExitConfirm(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	exitCalls := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("0\n0\n1\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Option"})
+	router.SetOnExitConfirm(func(_ context.Context) bool {
+		exitCalls++
+		return exitCalls > 1
+	})
+
+	router.Run(ctx)
+
+	if exitCalls != 2 {
+		t.Errorf("expected 2 OnExitConfirm calls, got %d", exitCalls)
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 2 {
+		t.Errorf("expected the loop to end after 2 prompts, got %d", prompts)
+	}
+}
+
+func TestBackLabelAndKey(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithTablePrinter(InlinePrinter{}),
+		WithBackLabelAndKey("b", "<-Back"),
+		WithInputOutput(strings.NewReader("1\nb\n0\n"), &output),
+	)
+	router.Group("Group")
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "b) <-Back") {
+		t.Errorf("custom Exit/<-Back row is not rendered:\n%s", output.String())
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 3 {
+		t.Errorf("expected 3 prompts, got %d", prompts)
+	}
+}
@@ -0,0 +1,29 @@
+package cmdrouter
+
+import "context"
+
+// WithFallbackHandler sets a handler receiving the input that doesn't select any option,
+// instead of reporting it as invalid, e.g. to accept free-form commands at the prompt.
+// Option numbers, hotkeys and other prompt commands take precedence. The handler runs
+// with the global middlewares of the router, and the menu is shown again after it returns.
+func WithFallbackHandler(handler func(ctx context.Context, input string) error) Setting {
+	return func(c *CmdRouter) {
+		c.fallback = handler
+	}
+}
+
+// runFallback runs the fallback handler with the input if it is set and the input isn't empty.
+// It reports whether the input was handled.
+func (c *CmdRouter) runFallback(ctx context.Context, input string) bool {
+	if c.fallback == nil || input == "" {
+		return false
+	}
+
+	handler := applyMiddlewares(func(ctx context.Context) error {
+		return c.fallback(ctx, input)
+	}, c.middlewares)
+
+	_ = c.execute(withRouter(ctx, c), handler)
+
+	return true
+}
@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFallbackHandler(t *testing.T) {
+	var output bytes.Buffer
+
+	var inputs, calls []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithFallbackHandler(func(_ context.Context, input string) error {
+			inputs = append(inputs, input)
+			return nil
+		}),
+		WithMiddlewares(func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				calls = append(calls, "middleware")
+				return next(ctx)
+			}
+		}),
+		WithInputOutput(strings.NewReader("deploy staging\n1\n0\n"), &output),
+		WithOptions(Option{Name: "Status", Handler: func(context.Context) error { return nil }}),
+	)
+
+	router.Run(t.Context())
+
+	if strings.Join(inputs, ",") != "deploy staging" {
+		t.Errorf("fallback received %q, want [deploy staging]", inputs)
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("middleware ran %d times, want 2 (fallback and Status)", len(calls))
+	}
+
+	if strings.Contains(output.String(), "Invalid number") {
+		t.Errorf("unexpected invalid input message:\n%s", output.String())
+	}
+}
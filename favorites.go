@@ -0,0 +1,88 @@
+package cmdrouter
+
+import (
+	"context"
+	"slices"
+)
+
+// favoritesKey is the Store key of the IDs of the favorite options.
+const favoritesKey = "cmdrouter.favorites"
+
+// favoritesMenuName is the name of the group added by WithFavoritesMenu.
+const favoritesMenuName = "Favorites"
+
+// WithFavoritesMenu adds a "Favorites" group listing the commands marked with ToggleFavorite,
+// found by their IDs anywhere in the menu tree. The list is updated every time the group
+// is entered, and the commands run with the same middlewares as if the user had navigated
+// to them. The group is added after the options added so far and, like other groups,
+// inherits only the settings applied before it.
+func WithFavoritesMenu(enable bool) Setting {
+	return func(c *CmdRouter) {
+		if enable {
+			c.addFavoritesMenu()
+		}
+	}
+}
+
+// ToggleFavorite marks the option with the given ID (see Option.ID) as a favorite of the router
+// and its groups, or unmarks it if it is one already. It reports whether the option is
+// a favorite now. Favorites are kept in the Store and saved with SavePreferences.
+func (c *CmdRouter) ToggleFavorite(id string) bool {
+	favorites := c.Favorites()
+
+	if i := slices.Index(favorites, id); i >= 0 {
+		c.store.Set(favoritesKey, slices.Delete(favorites, i, i+1))
+		return false
+	}
+
+	c.store.Set(favoritesKey, append(favorites, id))
+
+	return true
+}
+
+// Favorites returns the IDs of the favorite options in the order they were marked.
+func (c *CmdRouter) Favorites() []string {
+	favorites, _ := c.store.Get(favoritesKey)
+	ids, _ := favorites.([]string)
+
+	return slices.Clone(ids)
+}
+
+// addFavoritesMenu adds the group listing the favorite commands.
+func (c *CmdRouter) addFavoritesMenu() {
+	favorites := c.Group(favoritesMenuName)
+	favorites.emptyMessage = "No favorites yet."
+
+	for i := range c.options {
+		if c.options[i].group == favorites {
+			c.options[i].Handler = func(ctx context.Context) error {
+				favorites.options = c.favoriteOptions(favorites)
+				favorites.Run(ctx)
+
+				return nil
+			}
+		}
+	}
+}
+
+// favoriteOptions returns the favorite commands of the menu tree, except the ones
+// listed in the favorites group itself, in the order they were marked.
+func (c *CmdRouter) favoriteOptions(favorites *CmdRouter) []Option {
+	found := map[string]treeOption{}
+
+	c.walkTree(func(t treeOption) {
+		if t.router != favorites {
+			found[t.option.id()] = t
+		}
+	})
+
+	var options []Option
+
+	for _, id := range c.Favorites() {
+		if t, ok := found[id]; ok {
+			options = append(options, Option{Name: t.option.Name, ID: id, Handler: t.handler()})
+		}
+	}
+
+	return options
+}
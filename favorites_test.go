@@ -0,0 +1,50 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFavoritesMenu(t *testing.T) {
+	var output bytes.Buffer
+
+	var calls []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n0\n"), &output),
+		WithFavoritesMenu(true),
+	)
+	dev := router.Group("Developer",
+		Option{Name: "Backend logs", Handler: func(context.Context) error {
+			calls = append(calls, "handler")
+			return nil
+		}},
+		Option{Name: "Frontend logs", Handler: func(context.Context) error { return nil }},
+	)
+	dev.AddMiddlewares(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			calls = append(calls, "developer middleware")
+			return next(ctx)
+		}
+	})
+
+	if !router.ToggleFavorite("backend-logs") {
+		t.Fatal("ToggleFavorite should mark the option as a favorite")
+	}
+
+	router.Run(t.Context())
+
+	if strings.Join(calls, ",") != "developer middleware,handler" {
+		t.Errorf("calls = %v, want the favorite to run with its middlewares", calls)
+	}
+
+	if !strings.Contains(output.String(), "| 1 | Backend logs |") || strings.Contains(output.String(), "| 2 | Frontend logs") {
+		t.Errorf("favorites menu should list only the favorite:\n%s", output.String())
+	}
+
+	if router.ToggleFavorite("backend-logs") || len(router.Favorites()) != 0 {
+		t.Errorf("ToggleFavorite should unmark the favorite, favorites: %v", router.Favorites())
+	}
+}
@@ -0,0 +1,93 @@
+package cmdrouter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithFuzzyMatch enables or disables selecting options by typing (part of) their names.
+// The input matches a name if its letters appear in the name in order, e.g. "lgn" matches
+// "Login". An exact name match always wins; if several names match, they are listed
+// with their numbers so the user can pick one.
+func WithFuzzyMatch(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.fuzzyMatch = enable
+	}
+}
+
+// fuzzyMatches returns the numbers of the options whose names match the input,
+// best matches first, shorter names first among equal matches. It returns nil if fuzzy matching is disabled.
+func (c *CmdRouter) fuzzyMatches(ctx context.Context, input string) []int {
+	if !c.fuzzyMatch || input == "" {
+		return nil
+	}
+
+	type match struct {
+		number int
+		score  int
+		length int
+	}
+
+	var matches []match
+
+	for i, option := range c.options {
+		name := option.displayName(ctx)
+		if strings.EqualFold(name, input) {
+			return []int{i + 1}
+		}
+
+		if score, ok := fuzzyScore(name, input); ok {
+			matches = append(matches, match{number: i + 1, score: score, length: len(name)})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.score, b.score), cmp.Compare(a.length, b.length))
+	})
+
+	numbers := make([]int, 0, len(matches))
+	for _, m := range matches {
+		numbers = append(numbers, m.number)
+	}
+
+	return numbers
+}
+
+// fuzzyScore reports whether the letters of the query appear in the name in order
+// (case-insensitive) and scores the match by where it ends: lower is better,
+// so matches starting early and spanning few characters win.
+func fuzzyScore(name, query string) (int, bool) {
+	name, query = strings.ToLower(name), strings.ToLower(query)
+
+	pos := 0
+
+	for _, r := range query {
+		i := strings.IndexRune(name[pos:], r)
+		if i < 0 {
+			return 0, false
+		}
+
+		pos += i + utf8.RuneLen(r)
+	}
+
+	return pos, true
+}
+
+// showShortlist lists the options matching the input, so the user can pick one by number.
+func (c *CmdRouter) showShortlist(ctx context.Context, numbers []int) {
+	_, _ = fmt.Fprintf(c.out, "Did you mean: %s?\n", c.optionList(ctx, numbers))
+}
+
+// optionList lists the options with their numbers, e.g. "3) Log, 4) Login".
+func (c *CmdRouter) optionList(ctx context.Context, numbers []int) string {
+	items := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		items = append(items, fmt.Sprintf("%d) %s", number, c.options[number-1].displayName(ctx)))
+	}
+
+	return strings.Join(items, ", ")
+}
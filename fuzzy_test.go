@@ -0,0 +1,42 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	option := func(name string) Option {
+		return Option{Name: name, Handler: func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithFuzzyMatch(true),
+		WithOptions(option("Logout"), option("Login"), option("Log"), option("Status")),
+		WithInputOutput(strings.NewReader("lgn\nlog\nlo\n2\nxyz\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	if strings.Join(executed, ",") != "Login,Log,Login" {
+		t.Errorf("unexpected executed options: %v", executed)
+	}
+
+	if !strings.Contains(output.String(), "Did you mean: 3) Log, 2) Login, 1) Logout?") {
+		t.Errorf("shortlist is not shown for an ambiguous input:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "Invalid number. Try again.") {
+		t.Error("input matching nothing was not rejected")
+	}
+}
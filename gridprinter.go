@@ -0,0 +1,123 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GridPrinter prints options in several columns, for menus with many short options,
+// e.g. twelve options as three columns of four.
+// Every option keeps its number, so the selection doesn't depend on the layout.
+// The headers are ignored.
+type GridPrinter struct {
+	// Columns is the number of columns. Zero means as many as fit into the terminal width,
+	// or Width if it is unknown, and a single column if neither is known.
+	Columns int
+	// Width is the maximum line width used when the terminal width is unknown.
+	// Zero means unlimited.
+	Width int
+	// RowMajor fills the rows left to right instead of the columns top to bottom.
+	RowMajor bool
+}
+
+// gridSeparator separates the columns of a GridPrinter.
+const gridSeparator = "  "
+
+// PrintTable implements the TablePrinter interface.
+func (p GridPrinter) PrintTable(out io.Writer, headers []string, rows [][]any) {
+	p.PrintTableWidth(out, headers, rows, 0)
+}
+
+// PrintTableWidth implements the WidthAwarePrinter interface.
+func (p GridPrinter) PrintTableWidth(out io.Writer, _ []string, rows [][]any, maxWidth int) {
+	if len(rows) == 0 {
+		return
+	}
+
+	if maxWidth <= 0 {
+		maxWidth = p.Width
+	}
+
+	items := make([]string, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, InlinePrinter{}.formatItem(row))
+	}
+
+	columns := p.Columns
+	if columns <= 0 {
+		columns = p.fittingColumns(items, maxWidth)
+	}
+
+	columns = min(columns, len(items))
+	widths := p.columnWidths(items, columns)
+
+	for line := range gridLines(len(items), columns) {
+		var builder strings.Builder
+
+		for column := range columns {
+			i := p.itemIndex(line, column, len(items), columns)
+			if i >= len(items) {
+				continue
+			}
+
+			if column > 0 {
+				builder.WriteString(gridSeparator)
+			}
+
+			builder.WriteString(items[i])
+			builder.WriteString(strings.Repeat(" ", widths[column]-displayWidth(items[i])))
+		}
+
+		_, _ = fmt.Fprintln(out, strings.TrimRight(builder.String(), " "))
+	}
+}
+
+// gridLines returns the number of lines of a grid of n items in the given number of columns.
+func gridLines(n, columns int) int {
+	return (n + columns - 1) / columns
+}
+
+// itemIndex returns the index of the item shown at the line and column of the grid.
+func (p GridPrinter) itemIndex(line, column, n, columns int) int {
+	if p.RowMajor {
+		return line*columns + column
+	}
+
+	return column*gridLines(n, columns) + line
+}
+
+// columnWidths returns the display width of every column of the grid.
+func (p GridPrinter) columnWidths(items []string, columns int) []int {
+	widths := make([]int, columns)
+
+	for line := range gridLines(len(items), columns) {
+		for column := range columns {
+			if i := p.itemIndex(line, column, len(items), columns); i < len(items) {
+				widths[column] = max(widths[column], displayWidth(items[i]))
+			}
+		}
+	}
+
+	return widths
+}
+
+// fittingColumns returns the largest number of columns whose lines fit into maxWidth.
+func (p GridPrinter) fittingColumns(items []string, maxWidth int) int {
+	if maxWidth <= 0 {
+		return 1
+	}
+
+	for columns := len(items); columns > 1; columns-- {
+		width := len(gridSeparator) * (columns - 1)
+		for _, w := range p.columnWidths(items, columns) {
+			width += w
+		}
+
+		if width <= maxWidth {
+			return columns
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,67 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGridPrinter(t *testing.T) {
+	var output bytes.Buffer
+
+	rows := [][]any{
+		{1, "Build"},
+		{2, "Test"},
+		{3, "Lint"},
+		{4, "Docs"},
+		{5, "Deploy"},
+		{6, "Release"},
+	}
+
+	GridPrinter{Columns: 2}.PrintTable(&output, []string{"#", "Menu"}, rows)
+
+	expected := "1) Build  4) Docs\n2) Test   5) Deploy\n3) Lint   6) Release\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	GridPrinter{Columns: 2, RowMajor: true}.PrintTable(&output, []string{"#", "Menu"}, rows)
+
+	expected = "1) Build   2) Test\n3) Lint    4) Docs\n5) Deploy  6) Release\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	GridPrinter{Width: 30}.PrintTable(&output, []string{"#", "Menu"}, rows)
+
+	expected = "1) Build  3) Lint  5) Deploy\n2) Test   4) Docs  6) Release\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestGridPrinterSelection(t *testing.T) {
+	var output bytes.Buffer
+
+	selected := ""
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithTablePrinter(GridPrinter{Columns: 2}),
+		WithInputOutput(strings.NewReader("4\n0\n"), &output),
+	)
+	for _, name := range []string{"Build", "Test", "Lint", "Docs", "Deploy", "Release"} {
+		router.AddOptions(Option{Name: name, Handler: func(context.Context) error {
+			selected = name
+			return nil
+		}})
+	}
+
+	router.Run(t.Context())
+
+	if selected != "Docs" {
+		t.Errorf("expected option 4 to be Docs, got %q", selected)
+	}
+}
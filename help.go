@@ -0,0 +1,40 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// helpPrefix asks for the details of an option instead of running it, e.g. "?2".
+const helpPrefix = "?"
+
+// showHelp prints the details of the option with the given number.
+// It returns false if the number is not a valid option number.
+func (c *CmdRouter) showHelp(number string) bool {
+	n, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil || n <= 0 || n > len(c.options) {
+		return false
+	}
+
+	option := c.options[n-1]
+
+	_, _ = fmt.Fprintf(c.out, "\n%s\n", option.Name)
+	_, _ = fmt.Fprintf(c.out, "  Path: %s\n", c.optionPath(option.Name))
+
+	if option.Description != "" {
+		_, _ = fmt.Fprintf(c.out, "  Description: %s\n", option.Description)
+	}
+
+	if option.Hotkey != "" {
+		_, _ = fmt.Fprintf(c.out, "  Hotkey: %s\n", option.Hotkey)
+	}
+
+	if option.group != nil {
+		_, _ = fmt.Fprintf(c.out, "  Opens a submenu with %d options.\n", len(option.group.options))
+	}
+
+	_, _ = fmt.Fprintln(c.out)
+
+	return true
+}
@@ -0,0 +1,47 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOptionHelp(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := false
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("?1\n?9\n0\n"), &output),
+	)
+	router.AddOptions(Option{
+		Name:        "Login",
+		Description: "Signs in with the saved credentials.",
+		Handler: func(_ context.Context) error {
+			executed = true
+			return nil
+		},
+	})
+
+	router.Run(ctx)
+
+	if executed {
+		t.Error("Handler was executed by the help input")
+	}
+
+	for _, s := range []string{"Path: > Menu > Login", "Description: Signs in with the saved credentials."} {
+		if !strings.Contains(output.String(), s) {
+			t.Errorf("help misses %q:\n%s", s, output.String())
+		}
+	}
+
+	if menus := strings.Count(output.String(), "| # | Menu  |"); menus != 2 {
+		t.Errorf("expected the menu to be shown again after the help, got %d menus", menus)
+	}
+
+	if !strings.Contains(output.String(), "Invalid number. Try again.") {
+		t.Error("help for an unknown option was not rejected")
+	}
+}
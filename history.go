@@ -0,0 +1,65 @@
+package cmdrouter
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// historySize is the maximum number of entries kept in the history.
+const historySize = 100
+
+// HistoryKind is the kind of a history entry.
+type HistoryKind int
+
+const (
+	// HistoryNavigate is recorded when a group is opened.
+	HistoryNavigate HistoryKind = iota
+	// HistoryCommand is recorded when a command is run.
+	HistoryCommand
+)
+
+// HistoryEntry is a single navigation or command execution.
+type HistoryEntry struct {
+	Kind HistoryKind
+	Name string    // Name of the selected option.
+	ID   string    // Stable ID of the selected option, see Option.ID.
+	Path string    // Full path of the selected option, e.g. "> Main Menu > Login".
+	Time time.Time // When the option was selected.
+}
+
+// history is a bounded log of selections shared by a router and its groups.
+type history struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+}
+
+// add appends the entry, dropping the oldest one when the history is full.
+func (h *history) add(entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) == historySize {
+		h.entries = slices.Delete(h.entries, 0, 1)
+	}
+	h.entries = append(h.entries, entry)
+}
+
+// History returns the navigations and commands recorded in the whole menu tree,
+// from oldest to newest. Only the last 100 entries are kept.
+func (c *CmdRouter) History() []HistoryEntry {
+	c.history.mu.Lock()
+	defer c.history.mu.Unlock()
+
+	return slices.Clone(c.history.entries)
+}
+
+// recordHistory adds the selection of the option to the history.
+func (c *CmdRouter) recordHistory(option Option, path string) {
+	kind := HistoryCommand
+	if option.group != nil {
+		kind = HistoryNavigate
+	}
+
+	c.history.add(HistoryEntry{Kind: kind, Name: option.Name, ID: option.id(), Path: path, Time: c.now()})
+}
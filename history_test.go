@@ -0,0 +1,55 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	handler := func(_ context.Context) error { return nil }
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n2\n0\n"), &output),
+	)
+	router.Group("Group", Option{Name: "Leaf", Handler: handler})
+	router.AddOptions(Option{Name: "Login", Handler: handler})
+
+	router.Run(ctx)
+
+	expected := []HistoryEntry{
+		{Kind: HistoryNavigate, Name: "Group", Path: "> Menu > Group"},
+		{Kind: HistoryCommand, Name: "Leaf", Path: "> Menu > Group > Leaf"},
+		{Kind: HistoryCommand, Name: "Login", Path: "> Menu > Login"},
+	}
+
+	entries := router.History()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %+v", len(expected), entries)
+	}
+
+	for i, entry := range entries {
+		if entry.Kind != expected[i].Kind || entry.Name != expected[i].Name || entry.Path != expected[i].Path {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], entry)
+		}
+
+		if entry.Time.IsZero() {
+			t.Errorf("entry %d has no time", i)
+		}
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	var h history
+	for i := range historySize + 10 {
+		h.add(HistoryEntry{Name: strings.Repeat("x", i)})
+	}
+
+	if len(h.entries) != historySize || len(h.entries[0].Name) != 10 {
+		t.Errorf("history is not bounded correctly: %d entries", len(h.entries))
+	}
+}
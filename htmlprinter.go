@@ -0,0 +1,46 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLPrinter prints tables as minimal HTML, e.g. for embedding menus in web docs.
+//
+//	<table>
+//	<thead><tr><th>#</th><th>Menu</th></tr></thead>
+//	<tbody>
+//	<tr><td>1</td><td>Login</td></tr>
+//	<tr><td>0</td><td>Exit</td></tr>
+//	</tbody>
+//	</table>
+type HTMLPrinter struct{}
+
+// PrintTable implements the TablePrinter interface.
+func (p HTMLPrinter) PrintTable(out io.Writer, headers []string, rows [][]any) {
+	var b strings.Builder
+
+	b.WriteString("<table>\n<thead><tr>")
+
+	for _, header := range headers {
+		b.WriteString("<th>" + html.EscapeString(header) + "</th>")
+	}
+
+	b.WriteString("</tr></thead>\n<tbody>\n")
+
+	for _, row := range rows {
+		b.WriteString("<tr>")
+
+		for _, cell := range row {
+			b.WriteString("<td>" + html.EscapeString(fmt.Sprint(cell)) + "</td>")
+		}
+
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("</tbody>\n</table>\n")
+
+	_, _ = io.WriteString(out, b.String())
+}
@@ -0,0 +1,27 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHTMLPrinter(t *testing.T) {
+	var output bytes.Buffer
+
+	HTMLPrinter{}.PrintTable(&output, []string{"#", "Menu"}, [][]any{
+		{1, "a < b"},
+		{0, "Exit"},
+	})
+
+	expected := "<table>\n" +
+		"<thead><tr><th>#</th><th>Menu</th></tr></thead>\n" +
+		"<tbody>\n" +
+		"<tr><td>1</td><td>a &lt; b</td></tr>\n" +
+		"<tr><td>0</td><td>Exit</td></tr>\n" +
+		"</tbody>\n" +
+		"</table>\n"
+
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
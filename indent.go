@@ -0,0 +1,55 @@
+package cmdrouter
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// WithHandlerIndent indents every line that commands print to Out(ctx) by n spaces,
+// so that their output is visually nested under the menu. Blank lines are not indented.
+func WithHandlerIndent(n int) Setting {
+	return func(c *CmdRouter) {
+		c.handlerIndent = n
+	}
+}
+
+// indented wraps the command handler so that its output to Out(ctx) is indented if it is enabled.
+func (c *CmdRouter) indented(handler Handler) Handler {
+	if c.handlerIndent <= 0 {
+		return handler
+	}
+
+	prefix := strings.Repeat(" ", c.handlerIndent)
+
+	return func(ctx context.Context) error {
+		return handler(context.WithValue(ctx, outKey{}, &indentWriter{out: Out(ctx), prefix: prefix, lineStart: true}))
+	}
+}
+
+// indentWriter writes the prefix before every non-empty line written through it.
+type indentWriter struct {
+	out       io.Writer
+	prefix    string
+	lineStart bool // Whether the next byte starts a line.
+}
+
+// Write implements io.Writer.
+func (w *indentWriter) Write(b []byte) (int, error) {
+	buf := make([]byte, 0, len(b))
+
+	for _, ch := range b {
+		if w.lineStart && ch != '\n' {
+			buf = append(buf, w.prefix...)
+		}
+
+		buf = append(buf, ch)
+		w.lineStart = ch == '\n'
+	}
+
+	if _, err := w.out.Write(buf); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
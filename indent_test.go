@@ -0,0 +1,30 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHandlerIndent(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithHandlerIndent(2),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+		WithOptions(Option{Name: "Status", Handler: func(ctx context.Context) error {
+			_, _ = fmt.Fprint(Out(ctx), "line 1\n\nline")
+			_, _ = fmt.Fprintln(Out(ctx), " 2")
+
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if !strings.Contains(output.String(), "\n  line 1\n\n  line 2\n") {
+		t.Errorf("handler output not indented:\n%s", output.String())
+	}
+}
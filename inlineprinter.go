@@ -0,0 +1,70 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InlinePrinter prints options horizontally instead of a table,
+// wrapping them to the available width, e.g. "1) Login  2) View Profile  0) Exit".
+// The headers are ignored.
+type InlinePrinter struct {
+	// Width is the maximum line width used when the terminal width is unknown.
+	// Zero means unlimited.
+	Width int
+}
+
+// PrintTable implements the TablePrinter interface.
+func (p InlinePrinter) PrintTable(out io.Writer, headers []string, rows [][]any) {
+	p.PrintTableWidth(out, headers, rows, 0)
+}
+
+// PrintTableWidth implements the WidthAwarePrinter interface.
+func (p InlinePrinter) PrintTableWidth(out io.Writer, _ []string, rows [][]any, maxWidth int) {
+	const separator = "  "
+
+	if maxWidth <= 0 {
+		maxWidth = p.Width
+	}
+
+	var line strings.Builder
+
+	for _, row := range rows {
+		item := p.formatItem(row)
+
+		if line.Len() > 0 && maxWidth > 0 &&
+			displayWidth(line.String())+len(separator)+displayWidth(item) > maxWidth {
+			_, _ = fmt.Fprintln(out, line.String())
+			line.Reset()
+		}
+
+		if line.Len() > 0 {
+			line.WriteString(separator)
+		}
+		line.WriteString(item)
+	}
+
+	if line.Len() > 0 {
+		_, _ = fmt.Fprintln(out, line.String())
+	}
+}
+
+// formatItem formats a row as "<number>) <cells...>", or only the cells for rows
+// without a number, such as section headers.
+func (InlinePrinter) formatItem(row []any) string {
+	if len(row) == 0 {
+		return ""
+	}
+
+	cells := make([]string, 0, len(row)-1)
+	for _, cell := range row[1:] {
+		cells = append(cells, fmt.Sprint(cell))
+	}
+
+	if row[0] == "" {
+		return strings.Join(cells, " ")
+	}
+
+	return fmt.Sprintf("%v) %s", row[0], strings.Join(cells, " "))
+}
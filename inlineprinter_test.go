@@ -0,0 +1,32 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInlinePrinter(t *testing.T) {
+	var output bytes.Buffer
+
+	rows := [][]any{
+		{1, "Login"},
+		{2, "Profile"},
+		{3, "Settings"},
+		{0, "Exit"},
+	}
+
+	InlinePrinter{Width: 24}.PrintTable(&output, []string{"#", "Menu"}, rows)
+
+	expected := "1) Login  2) Profile\n3) Settings  0) Exit\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	output.Reset()
+	InlinePrinter{}.PrintTable(&output, []string{"#", "Menu"}, rows)
+
+	expected = "1) Login  2) Profile  3) Settings  0) Exit\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
@@ -0,0 +1,186 @@
+package cmdrouter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// lineReader reads input line by line. It is shared by a router and its groups,
+// so that input buffered by one of them is not lost for the others.
+// Reads can be interrupted by context cancellation: the line being read
+// is then returned by the next call.
+type lineReader struct {
+	next    func() (string, error) // Reads the next line, blocking until it is available.
+	pending chan lineResult        // Result of the read in progress, nil if there is none.
+	record  io.Writer              // Receives every line read, nil if the session isn't recorded.
+	raw     func() (func(), error) // Switches the terminal to the mode next reads in, returning the restore function; nil if none.
+
+	buffered func() bool // Reports whether a whole line was read ahead from the input, nil if unknown.
+}
+
+// lineResult is the result of a single line read.
+type lineResult struct {
+	line string
+	err  error
+}
+
+// newLineReader creates a lineReader for the given input stream.
+func newLineReader(in io.Reader) *lineReader {
+	reader := bufio.NewReader(in)
+
+	return &lineReader{
+		next: func() (string, error) {
+			line, err := reader.ReadString('\n')
+			if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+				return "", err
+			}
+
+			return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+		},
+		buffered: func() bool {
+			data, _ := reader.Peek(reader.Buffered())
+			return bytes.IndexByte(data, '\n') >= 0
+		},
+	}
+}
+
+// readLine returns the next line without the trailing newline.
+// It returns io.EOF at the end of input and ctx.Err() if the context is done first.
+// The terminal mode set by raw is restored here rather than by the read in progress,
+// which stays blocked if the context is done first.
+func (r *lineReader) readLine(ctx context.Context) (string, error) {
+	if r.raw != nil {
+		restore, err := r.raw()
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+	}
+
+	r.startRead()
+
+	select {
+	case result := <-r.pending:
+		r.pending = nil
+
+		if result.err == nil && r.record != nil {
+			_, _ = io.WriteString(r.record, result.line+"\n")
+		}
+
+		return result.line, result.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// startRead starts reading the next line unless a read is already in progress.
+func (r *lineReader) startRead() {
+	if r.pending != nil {
+		return
+	}
+
+	pending := make(chan lineResult, 1)
+	r.pending = pending
+
+	go func() {
+		line, err := r.next()
+		pending <- lineResult{line: line, err: err}
+	}()
+}
+
+// peekBuffered returns the next line if it is available without waiting for the input,
+// e.g. because it was pasted or piped together with the previous lines, without consuming it.
+// It reports false otherwise. The check doesn't depend on timing: it only looks
+// at a read that has completed already or at the lines read ahead from the input.
+func (r *lineReader) peekBuffered() (string, bool) {
+	if r.pending == nil {
+		if r.buffered == nil || !r.buffered() {
+			return "", false
+		}
+
+		r.startRead()
+
+		result := <-r.pending
+		r.pending = make(chan lineResult, 1)
+		r.pending <- result
+
+		return result.line, result.err == nil
+	}
+
+	select {
+	case result := <-r.pending:
+		r.pending = make(chan lineResult, 1)
+		r.pending <- result
+
+		return result.line, result.err == nil
+	default:
+		return "", false
+	}
+}
+
+// ReadBlock prints the prompt and reads lines from the router input until a line
+// equal to terminator ("." if empty). It returns the lines read joined with "\n".
+// At the end of input it returns the text read so far and io.EOF.
+// If the context is done first, it returns the text read so far and ctx.Err().
+//
+// It is intended for handlers that need multi-line input, e.g. a pasted config.
+func (c *CmdRouter) ReadBlock(ctx context.Context, prompt, terminator string) (string, error) {
+	const defaultTerminator = "."
+
+	if terminator == "" {
+		terminator = defaultTerminator
+	}
+
+	_, _ = fmt.Fprint(c.out, prompt)
+
+	var lines []string
+
+	for {
+		line, err := c.input.readLine(ctx)
+		if err != nil {
+			return strings.Join(lines, "\n"), err
+		}
+
+		if line == terminator {
+			return strings.Join(lines, "\n"), nil
+		}
+
+		lines = append(lines, line)
+	}
+}
+
+// ErrNoMatch is returned by ReadMatching when no input matched the pattern.
+var ErrNoMatch = errors.New("input doesn't match the pattern")
+
+// maxMatchAttempts is the number of times ReadMatching asks for the input.
+const maxMatchAttempts = 3
+
+// ReadMatching prints the prompt and reads a line from the router input, asking again
+// until the line matches re. After 3 unmatched lines it returns ErrNoMatch.
+// At the end of input it returns io.EOF, and ctx.Err() if the context is done first.
+//
+// It is intended for handlers that need formatted input, e.g. a version or an email.
+func (c *CmdRouter) ReadMatching(ctx context.Context, prompt string, re *regexp.Regexp) (string, error) {
+	for range maxMatchAttempts {
+		_, _ = fmt.Fprint(c.out, prompt)
+
+		line, err := c.input.readLine(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		if line = strings.TrimSpace(line); re.MatchString(line) {
+			return line, nil
+		}
+
+		_, _ = fmt.Fprintln(c.out, "Invalid input. Try again.")
+	}
+
+	return "", fmt.Errorf("%w %s", ErrNoMatch, re)
+}
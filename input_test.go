@@ -0,0 +1,201 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeadlinePrompt(t *testing.T) {
+	var output bytes.Buffer
+
+	in, writer := io.Pipe()
+	defer writer.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(Option{Name: "Test Option"}),
+		WithInputOutput(in, &output),
+		WithClock(func() time.Time { return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC) }),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		router.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the deadline")
+	}
+
+	if !strings.Contains(output.String(), "Enter option number (1s left): ") {
+		t.Errorf("remaining time is not shown in the prompt:\n%s", output.String())
+	}
+}
+
+func TestLineReaderSharedBetweenGroups(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n1\n1\n0\n0\n"), &output),
+	)
+	router.Group("Group", Option{Name: "Leaf", Handler: func(_ context.Context) error {
+		executed++
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if executed != 2 {
+		t.Errorf("expected the leaf to run twice, got %d", executed)
+	}
+}
+
+func TestReadBlock(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var block string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\nfirst\nsecond\nthird\n.\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Paste config", Handler: func(ctx context.Context) error {
+		var err error
+		block, err = router.ReadBlock(ctx, "Paste config, end with '.':\n", "")
+		return err
+	}})
+
+	router.Run(ctx)
+
+	if block != "first\nsecond\nthird" {
+		t.Errorf("unexpected block %q", block)
+	}
+}
+
+func TestReadBlockEOF(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("first\nsecond"), &output),
+	)
+
+	block, err := router.ReadBlock(t.Context(), "", "END")
+	if !errors.Is(err, io.EOF) || block != "first\nsecond" {
+		t.Errorf("unexpected result %q, %v", block, err)
+	}
+}
+
+func TestReadMatching(t *testing.T) {
+	var output bytes.Buffer
+
+	version := regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1.2\nv1.2.3\nx\ny\nz\n"), &output),
+	)
+
+	value, err := router.ReadMatching(t.Context(), "Version: ", version)
+	if err != nil || value != "v1.2.3" {
+		t.Errorf("unexpected result %q, %v", value, err)
+	}
+
+	if strings.Count(output.String(), "Invalid input. Try again.") != 1 {
+		t.Errorf("bad value was not rejected once:\n%s", output.String())
+	}
+
+	if _, err := router.ReadMatching(t.Context(), "Version: ", version); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch after exhausting the attempts, got %v", err)
+	}
+}
+
+func TestConfirmTimeout(t *testing.T) {
+	var output bytes.Buffer
+
+	in, _ := io.Pipe()
+
+	router := NewCmdRouterWithSettings("Menu", WithInputOutput(in, &output))
+
+	ok, err := router.ConfirmTimeout(t.Context(), "Proceed?", 20*time.Millisecond, true)
+	if err != nil || !ok {
+		t.Errorf("unexpected result %v, %v, want the default", ok, err)
+	}
+
+	if !strings.Contains(output.String(), "Proceed? [Y/n] (0s): ") {
+		t.Errorf("prompt not shown:\n%s", output.String())
+	}
+
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	WithClock(func() time.Time { return frozen })(router)
+	router.terminalFunc = func(any) bool { return true }
+
+	ok, err = router.ConfirmTimeout(t.Context(), "Proceed?", 20*time.Millisecond, true)
+	if err != nil || !ok {
+		t.Errorf("unexpected result %v, %v, want the default after the real timeout with a frozen clock", ok, err)
+	}
+
+	WithClock(nil)(router)
+	router.terminalFunc = isTerminal
+	router.SetInputOutput(strings.NewReader("maybe\nn\n"), &output)
+
+	ok, err = router.ConfirmTimeout(t.Context(), "Proceed?", time.Minute, true)
+	if err != nil || ok {
+		t.Errorf("unexpected result %v, %v, want the answer", ok, err)
+	}
+}
+
+func TestAssumeYes(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+	handler := func(_ context.Context) error {
+		runs++
+		return nil
+	}
+
+	router := NewCmdRouterWithSettings("Menu", WithInputOutput(strings.NewReader(""), &output))
+	router.AddOptions(
+		Option{Name: "Reset", Confirm: "Reset all settings?", Handler: handler},
+		Option{Name: "Drop", ConfirmTyped: "production", Handler: handler},
+	)
+
+	if err := router.Invoke(AssumeYes(t.Context()), "Reset"); err != nil || runs != 1 {
+		t.Fatalf("confirmed option didn't run with AssumeYes: %v", err)
+	}
+
+	if err := router.Invoke(AssumeYes(t.Context()), "Drop"); !errors.Is(err, io.EOF) || runs != 1 {
+		t.Fatalf("typed challenge was skipped with AssumeYes: %v", err)
+	}
+
+	if err := router.Invoke(AssumeYesTyped(t.Context()), "Drop"); err != nil || runs != 2 {
+		t.Fatalf("typed challenge wasn't skipped with AssumeYesTyped: %v", err)
+	}
+
+	if strings.Contains(output.String(), "Reset all settings?") {
+		t.Errorf("confirmation asked with AssumeYes:\n%s", output.String())
+	}
+
+	router.SetInputOutput(strings.NewReader("1\nn\n2\nstaging\n0\n"), &output)
+	router.Run(t.Context())
+
+	if runs != 2 {
+		t.Errorf("declined options ran")
+	}
+
+	if strings.Count(output.String(), "Canceled.") != 2 {
+		t.Errorf("cancellation not reported:\n%s", output.String())
+	}
+}
@@ -0,0 +1,50 @@
+package cmdrouter
+
+import "strings"
+
+// maxInstantNumber is the highest option number that can be selected with a single key.
+const maxInstantNumber = 9
+
+// WithInstantKeys makes the menu act on single keys without waiting for Enter when both
+// input and output are terminals: an option number, if the menu has no more than nine options,
+// a hotkey or the recall key. Other input, e.g. multi-digit numbers, is still confirmed with Enter.
+// The terminal is switched to cbreak mode only while the prompt is read and restored afterwards.
+// Otherwise the input is read line by line as usual.
+func WithInstantKeys(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.instantKeys = enable
+		c.setupEditor()
+	}
+}
+
+// selectionInput returns the reader used for the option number prompt,
+// set up to complete the selection on a single key if instant keys are enabled.
+func (c *CmdRouter) selectionInput() *lineReader {
+	input := c.promptInput()
+	if c.instantKeys && c.keyEditor != nil {
+		c.keyEditor.instant = c.isInstantKey
+	}
+
+	return input
+}
+
+// isInstantKey reports whether the key typed first at the prompt is a complete selection.
+func (c *CmdRouter) isInstantKey(key string) bool {
+	if key >= "0" && key <= "9" {
+		return !c.multiSelect && len(c.options) <= maxInstantNumber
+	}
+
+	if c.isRecallInput(key) {
+		return true
+	}
+
+	for _, option := range c.options {
+		if len(option.Hotkey) > len(key) && strings.HasPrefix(strings.ToLower(option.Hotkey), strings.ToLower(key)) {
+			return false
+		}
+	}
+
+	_, ok := c.hotkeyOption(key)
+
+	return ok
+}
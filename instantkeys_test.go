@@ -0,0 +1,66 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestInstantKeysFallback(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInstantKeys(true),
+		WithInputOutput(strings.NewReader("1\n10\n0\n"), &output),
+	)
+	for i := range 10 {
+		name := "Option " + string(rune('A'+i))
+		router.AddOptions(Option{Name: name, Handler: func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}})
+	}
+
+	router.Run(ctx)
+
+	if router.editor != nil {
+		t.Error("Key reader is used for non-terminal input")
+	}
+
+	if strings.Join(executed, ",") != "Option A,Option J" {
+		t.Errorf("expected Option A and Option J to run, got %v", executed)
+	}
+}
+
+func TestInstantKeys(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouter("Menu")
+	router.AddOptions(
+		Option{Name: "List", Hotkey: "l"},
+		Option{Name: "Log", Hotkey: "lg"},
+		Option{Name: "Quit", Hotkey: "q"},
+	)
+
+	editor := &lineEditor{
+		in:      strings.NewReader("2q" + "lg\n" + "x\n"),
+		out:     &output,
+		instant: router.isInstantKey,
+	}
+
+	for _, want := range []string{"2", "q", "lg", "x"} {
+		line, err := editor.readLine()
+		if err != nil || line != want {
+			t.Errorf("expected %q, got %q (%v)", want, line, err)
+		}
+	}
+
+	router.multiSelect = true
+	if router.isInstantKey("2") {
+		t.Error("Number is instant with multiple selection enabled")
+	}
+}
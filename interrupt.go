@@ -0,0 +1,70 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+// WithCancelOnInterrupt makes Ctrl-C cancel the running command and return to the menu
+// instead of terminating the program. Handlers must honor context cancellation to benefit.
+func WithCancelOnInterrupt(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.CancelOnInterrupt(enable)
+	}
+}
+
+// CancelOnInterrupt enables or disables canceling the running command with Ctrl-C
+// for the current router and its groups.
+func (c *CmdRouter) CancelOnInterrupt(enable bool) {
+	c.cancelOnInterrupt = enable
+}
+
+// notifyInterrupts relays os.Interrupt signals to the returned channel until stop is called.
+func notifyInterrupts() (signals <-chan os.Signal, stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+
+	return ch, func() { signal.Stop(ch) }
+}
+
+// interruptible wraps the command handler so that an interrupt cancels its context
+// if canceling on interrupt is enabled. The interrupt handling is installed only
+// while the handler runs, so Ctrl-C at the prompt keeps its default behavior.
+func (c *CmdRouter) interruptible(handler Handler) Handler {
+	if !c.cancelOnInterrupt {
+		return handler
+	}
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		signals, stop := c.interrupts()
+		defer stop()
+
+		interrupted := make(chan struct{})
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-signals:
+				close(interrupted)
+				cancel()
+			case <-done:
+			}
+		}()
+
+		err := handler(ctx)
+
+		select {
+		case <-interrupted:
+			_, _ = fmt.Fprintln(c.out, "Canceled.")
+		default:
+		}
+
+		return err
+	}
+}
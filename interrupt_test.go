@@ -0,0 +1,59 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInterruptCancelsRunningCommand(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	signals := make(chan os.Signal, 1)
+	started := make(chan struct{})
+
+	var handlerErr error
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithCancelOnInterrupt(true),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.interrupts = func() (<-chan os.Signal, func()) {
+		return signals, func() {}
+	}
+	router.AddOptions(Option{Name: "Sleep", Handler: func(ctx context.Context) error {
+		close(started)
+
+		select {
+		case <-ctx.Done():
+			handlerErr = ctx.Err()
+		case <-time.After(time.Minute):
+		}
+
+		return handlerErr
+	}})
+
+	go func() {
+		<-started
+		signals <- os.Interrupt
+	}()
+
+	router.Run(ctx)
+
+	if !errors.Is(handlerErr, context.Canceled) {
+		t.Errorf("handler was not canceled, got %v", handlerErr)
+	}
+
+	if !strings.Contains(output.String(), "Canceled.") {
+		t.Error("Cancellation was not reported")
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 2 {
+		t.Errorf("expected the menu to resume with 2 prompts, got %d", prompts)
+	}
+}
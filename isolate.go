@@ -0,0 +1,64 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrHandlerExited is returned by isolated handlers whose goroutine exited
+// without returning, e.g. after calling runtime.Goexit.
+var ErrHandlerExited = errors.New("handler goroutine exited")
+
+// WithIsolatedHandlers makes every command run in its own goroutine, so that neither
+// a panic nor runtime.Goexit in a handler can stop the menu loop. The loop waits for
+// the goroutine to finish, so commands still run one at a time; the cost is one
+// goroutine per invocation, and handlers must not rely on running in the goroutine
+// that called Run (e.g. with runtime.LockOSThread).
+func WithIsolatedHandlers(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.isolated = enable
+	}
+}
+
+// isolate wraps the command handler so that it runs in its own goroutine if handler
+// isolation is enabled. Panics and goroutine exits are logged and returned as errors.
+func (c *CmdRouter) isolate(handler Handler) Handler {
+	if !c.isolated {
+		return handler
+	}
+
+	return func(ctx context.Context) error {
+		result := make(chan error, 1)
+		crashed := false
+
+		go func() {
+			returned := false
+
+			defer func() {
+				if returned {
+					return
+				}
+
+				crashed = true
+
+				if r := recover(); r != nil {
+					result <- fmt.Errorf("panic: %v", r)
+				} else {
+					result <- ErrHandlerExited
+				}
+			}()
+
+			err := handler(ctx)
+			returned = true
+			result <- err
+		}()
+
+		err := <-result
+		if crashed {
+			Logger(ctx).Error("handler", "err", err)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,44 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestIsolatedHandlers(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithIsolatedHandlers(true),
+		WithInputOutput(strings.NewReader("1\n2\n3\n0\n"), &output),
+	)
+	router.AddOptions(
+		Option{Name: "Exit goroutine", Handler: func(_ context.Context) error {
+			runtime.Goexit()
+			return nil
+		}},
+		Option{Name: "Panic", Handler: func(_ context.Context) error {
+			panic("boom")
+		}},
+		Option{Name: "Count", Handler: func(_ context.Context) error {
+			executed++
+			return nil
+		}},
+	)
+
+	router.Run(ctx)
+
+	if executed != 1 {
+		t.Errorf("the loop did not continue after the crashed handlers")
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 4 {
+		t.Errorf("expected 4 prompts, got %d", prompts)
+	}
+}
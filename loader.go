@@ -0,0 +1,72 @@
+package cmdrouter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnknownHandler is returned by LoadMenu when an option refers to a handler
+// missing from the handlers map.
+var ErrUnknownHandler = errors.New("unknown handler")
+
+// jsonMenu is the JSON definition of a menu loaded by LoadMenu.
+type jsonMenu struct {
+	Name    string       `json:"name"`
+	Options []jsonOption `json:"options"`
+	Group   []jsonMenu   `json:"group"`
+}
+
+// jsonOption is the JSON definition of a single command.
+type jsonOption struct {
+	Name    string `json:"name"`
+	Handler string `json:"handler"`
+}
+
+// LoadMenu creates a router from a JSON menu definition. Each option refers to
+// its handler by name in the handlers map. Groups can be nested to any depth.
+// In every menu the options are added before the groups.
+// The settings are applied to the root router before the groups are created,
+// so they are inherited by the whole tree.
+//
+//	{
+//	  "name": "Main Menu",
+//	  "options": [{"name": "Login", "handler": "login"}],
+//	  "group": [
+//	    {"name": "Developer", "options": [{"name": "Logs", "handler": "logs"}]}
+//	  ]
+//	}
+func LoadMenu(r io.Reader, handlers map[string]Handler, settings ...Setting) (*CmdRouter, error) {
+	var menu jsonMenu
+	if err := json.NewDecoder(r).Decode(&menu); err != nil {
+		return nil, fmt.Errorf("decode menu: %w", err)
+	}
+
+	router := NewCmdRouterWithSettings(menu.Name, settings...)
+	if err := router.loadMenu(menu, handlers); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
+
+// loadMenu adds the options and groups of the menu definition to the router.
+func (c *CmdRouter) loadMenu(menu jsonMenu, handlers map[string]Handler) error {
+	for _, option := range menu.Options {
+		handler, ok := handlers[option.Handler]
+		if !ok {
+			return fmt.Errorf("option %q: %w %q", option.Name, ErrUnknownHandler, option.Handler)
+		}
+
+		c.AddOptions(Option{Name: option.Name, Handler: handler})
+	}
+
+	for _, group := range menu.Group {
+		if err := c.Group(group.Name).loadMenu(group, handlers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
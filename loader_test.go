@@ -0,0 +1,53 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testMenu = `{
+	"name": "Main Menu",
+	"options": [{"name": "Login", "handler": "login"}],
+	"group": [
+		{"name": "Developer", "options": [{"name": "Logs", "handler": "logs"}]}
+	]
+}`
+
+func TestLoadMenu(t *testing.T) {
+	var output bytes.Buffer
+
+	var executed []string
+
+	handlers := map[string]Handler{
+		"login": func(_ context.Context) error {
+			executed = append(executed, "login")
+			return nil
+		},
+		"logs": func(_ context.Context) error {
+			executed = append(executed, "logs")
+			return nil
+		},
+	}
+
+	router, err := LoadMenu(strings.NewReader(testMenu), handlers,
+		WithInputOutput(strings.NewReader("2\n1\n0\n1\n0\n"), &output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router.Run(t.Context())
+
+	if strings.Join(executed, ",") != "logs,login" {
+		t.Errorf("unexpected executed handlers: %v", executed)
+	}
+}
+
+func TestLoadMenuUnknownHandler(t *testing.T) {
+	_, err := LoadMenu(strings.NewReader(testMenu), map[string]Handler{})
+	if !errors.Is(err, ErrUnknownHandler) {
+		t.Errorf("expected ErrUnknownHandler, got %v", err)
+	}
+}
@@ -2,8 +2,11 @@ package cmdrouter
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // DefaultRecoverMiddleware recovers from panics in the wrapped
@@ -20,14 +23,132 @@ func DefaultRecoverMiddleware(next Handler) Handler {
 	}
 }
 
-// DefaultLoggerMiddleware is a middleware that logs any error
-// returned by the wrapped handler using slog.Error.
+// DefaultLoggerMiddleware is a middleware that logs any error other than ErrRepeat
+// returned by the wrapped handler using the logger from Logger(ctx).
 func DefaultLoggerMiddleware(next Handler) Handler {
 	return func(ctx context.Context) error {
 		err := next(ctx)
-		if err != nil {
-			slog.Error("handler", "err", err)
+		if err != nil && !errors.Is(err, ErrRepeat) {
+			Logger(ctx).Error("handler", "err", err)
 		}
 		return err
 	}
 }
+
+// DefaultMiddleware returns the recommended set of middlewares:
+// DefaultLoggerMiddleware wrapping DefaultRecoverMiddleware. The recover middleware
+// is the inner one, so panics are converted to errors before the logger records them.
+func DefaultMiddleware() []Middleware {
+	return []Middleware{DefaultLoggerMiddleware, DefaultRecoverMiddleware}
+}
+
+// WithDefaults adds the middlewares returned by DefaultMiddleware to the CmdRouter.
+func WithDefaults() Setting {
+	return WithMiddlewares(DefaultMiddleware()...)
+}
+
+// ErrRateLimited is returned by RateLimitMiddleware when a handler is called too often.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitMiddleware limits how often each option can run.
+// It allows bursts of up to burst calls and then one call per every interval.
+// Rejected calls return ErrRateLimited without running the handler.
+//
+// Every option has its own limit, found by its router and OptionID, so the middleware
+// can be attached to a router to limit each of its commands separately.
+// Time is read with Now, so the limiter follows the clock set by WithClock.
+func RateLimitMiddleware(every time.Duration, burst int) Middleware {
+	var (
+		mu       sync.Mutex
+		limiters = map[optionKey]*tokenBucket{}
+	)
+
+	limiterFor := func(key optionKey) *tokenBucket {
+		mu.Lock()
+		defer mu.Unlock()
+
+		limiter, ok := limiters[key]
+		if !ok {
+			limiter = &tokenBucket{tokens: float64(burst), burst: float64(burst), every: every}
+			limiters[key] = limiter
+		}
+
+		return limiter
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			if !limiterFor(optionKeyOf(ctx, OptionID(ctx))).allow(Now(ctx)) {
+				return ErrRateLimited
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// tokenBucket is a minimal token bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	every  time.Duration
+	last   time.Time // Time of the last call, zero before the first one.
+}
+
+// allow refills the bucket for the time passed since the last call and takes one token if available.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.every > 0 && !b.last.IsZero() {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.every)
+	}
+	b.tokens = min(b.tokens, b.burst)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// Toggle switches a middleware created by NewToggle on and off at runtime.
+// It is safe for concurrent use.
+type Toggle struct {
+	disabled atomic.Bool
+}
+
+// Enable turns the middleware on.
+func (t *Toggle) Enable() {
+	t.disabled.Store(false)
+}
+
+// Disable turns the middleware off, so that it passes calls straight to the next handler.
+func (t *Toggle) Disable() {
+	t.disabled.Store(true)
+}
+
+// Enabled reports whether the middleware is on.
+func (t *Toggle) Enabled() bool {
+	return !t.disabled.Load()
+}
+
+// NewToggle wraps the middleware so that it can be switched on and off at runtime,
+// e.g. verbose logging flipped by a menu option. The middleware is initially enabled.
+// The state is checked on every call, so a change applies to the next command run.
+func NewToggle(m Middleware) (Middleware, *Toggle) {
+	toggle := &Toggle{}
+
+	return func(next Handler) Handler {
+		wrapped := m(next)
+
+		return func(ctx context.Context) error {
+			if toggle.Enabled() {
+				return wrapped(ctx)
+			}
+			return next(ctx)
+		}
+	}, toggle
+}
@@ -0,0 +1,203 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	ctx := t.Context()
+	calls := 0
+
+	handler := RateLimitMiddleware(time.Hour, 2)(func(_ context.Context) error {
+		calls++
+		return nil
+	})
+
+	for range 2 {
+		if err := handler(ctx); err != nil {
+			t.Fatalf("unexpected error within burst: %v", err)
+		}
+	}
+
+	if err := handler(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 handler calls, got %d", calls)
+	}
+}
+
+func TestWithDefaultsRecoversAndLogs(t *testing.T) {
+	var logs, output bytes.Buffer
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithDefaults(),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Panic", Handler: func(_ context.Context) error {
+		panic("boom")
+	}})
+
+	router.Run(t.Context())
+
+	if !strings.Contains(logs.String(), "panic: boom") {
+		t.Errorf("panic was not logged: %s", logs.String())
+	}
+}
+
+func TestNewToggle(t *testing.T) {
+	calls := 0
+
+	verbose, toggle := NewToggle(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			calls++
+			return next(ctx)
+		}
+	})
+
+	executed := 0
+	handler := verbose(func(_ context.Context) error {
+		executed++
+		return nil
+	})
+
+	_ = handler(t.Context())
+
+	toggle.Disable()
+	if toggle.Enabled() {
+		t.Error("toggle is enabled after Disable")
+	}
+	_ = handler(t.Context())
+
+	toggle.Enable()
+	_ = handler(t.Context())
+
+	if calls != 2 || executed != 3 {
+		t.Errorf("expected 2 middleware calls and 3 handler calls, got %d and %d", calls, executed)
+	}
+}
+
+func TestRemoveNamedMiddleware(t *testing.T) {
+	var calls []string
+
+	named := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	router := NewCmdRouter("Menu", Option{Name: "Run", Handler: func(context.Context) error { return nil }})
+	router.AddNamedMiddleware("trace", named("trace"))
+	router.AddNamedMiddleware("audit", named("audit"))
+
+	if !router.RemoveMiddleware("trace") {
+		t.Fatal("RemoveMiddleware(trace) = false, want true")
+	}
+
+	if router.RemoveMiddleware("trace") {
+		t.Error("RemoveMiddleware(trace) = true for a removed middleware")
+	}
+
+	if err := router.Invoke(t.Context(), "Run"); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(calls, ",") != "audit" {
+		t.Errorf("calls = %v, want [audit]", calls)
+	}
+}
+
+func TestNavMiddleware(t *testing.T) {
+	var output bytes.Buffer
+
+	var entered []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n2\n1\n0\n0\n"), &output),
+		WithOptions(Option{Name: "Status", Handler: func(context.Context) error { return nil }}),
+	)
+	router.AddNavMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			entered = append(entered, OptionID(ctx))
+			return next(ctx)
+		}
+	})
+	router.Group("Admin", Option{Name: "Users", Handler: func(context.Context) error { return nil }})
+
+	router.Run(t.Context())
+
+	if strings.Join(entered, ",") != "admin" {
+		t.Errorf("nav middleware ran for %v, want [admin]", entered)
+	}
+}
+
+func TestRateLimitMiddlewareWithClock(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithClock(func() time.Time { return now }),
+		WithMiddlewares(RateLimitMiddleware(time.Minute, 1)),
+		WithOptions(Option{Name: "Sync", Handler: func(context.Context) error { return nil }}),
+	)
+
+	if err := router.Invoke(ctx, "Sync"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := router.Invoke(ctx, "Sync"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+
+	if err := router.Invoke(ctx, "Sync"); err != nil {
+		t.Errorf("limit not refilled by the clock: %v", err)
+	}
+}
+
+func TestWithNilClock(t *testing.T) {
+	router := NewCmdRouterWithSettings("Menu", WithClock(nil))
+
+	if router.now().IsZero() {
+		t.Error("nil clock should fall back to time.Now")
+	}
+}
+
+func TestRateLimitMiddlewarePerOption(t *testing.T) {
+	ctx := t.Context()
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMiddlewares(RateLimitMiddleware(time.Hour, 1)),
+		WithOptions(
+			Option{Name: "Sync", Handler: func(context.Context) error { return nil }},
+			Option{Name: "Backup", Handler: func(context.Context) error { return nil }},
+		),
+	)
+
+	if err := router.Invoke(ctx, "Sync"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := router.Invoke(ctx, "Backup"); err != nil {
+		t.Errorf("Backup limited by the calls of Sync: %v", err)
+	}
+
+	if err := router.Invoke(ctx, "Sync"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// parseMultiSelection parses a list of option numbers and ranges, e.g. "1,3 5-7".
+// Every number must refer to an existing option.
+func (c *CmdRouter) parseMultiSelection(input string) ([]int, bool) {
+	tokens := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	var numbers []int
+
+	for _, token := range tokens {
+		first, last, isRange := strings.Cut(token, "-")
+		if !isRange {
+			last = first
+		}
+
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, false
+		}
+
+		to, err := strconv.Atoi(last)
+		if err != nil || from < 1 || to < from || to > len(c.options) {
+			return nil, false
+		}
+
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+	}
+
+	return numbers, true
+}
@@ -0,0 +1,82 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMultiSelect(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	handler := func(name string) Handler {
+		return func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMultiSelect(true),
+		WithOptions(
+			Option{Name: "First", Handler: handler("first")},
+			Option{Name: "Second", Handler: handler("second")},
+			Option{Name: "Third", Handler: handler("third")},
+		),
+		WithInputOutput(strings.NewReader("1,3\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	if strings.Join(executed, ",") != "first,third" {
+		t.Errorf("expected first and third to run, got %v", executed)
+	}
+}
+
+func TestMultiSelectStopsOnError(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMultiSelect(true),
+		WithOptions(
+			Option{Name: "Failing", Handler: func(_ context.Context) error {
+				executed++
+				return errors.New("failed")
+			}},
+			Option{Name: "Second", Handler: func(_ context.Context) error {
+				executed++
+				return nil
+			}},
+		),
+		WithInputOutput(strings.NewReader("1-2\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	if executed != 1 {
+		t.Errorf("expected execution to stop after the error, got %d handler calls", executed)
+	}
+}
+
+func TestParseMultiSelection(t *testing.T) {
+	router := NewCmdRouter("Menu", make([]Option, 7)...)
+
+	numbers, ok := router.parseMultiSelection("1,3 5-7")
+	if !ok || len(numbers) != 5 || numbers[2] != 5 || numbers[4] != 7 {
+		t.Errorf("unexpected selection: %v", numbers)
+	}
+
+	for _, input := range []string{"0,1", "1,8", "3-2", "a", ","} {
+		if _, ok := router.parseMultiSelection(input); ok {
+			t.Errorf("input %q should be rejected", input)
+		}
+	}
+}
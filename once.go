@@ -0,0 +1,47 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+)
+
+// sessionKey is the context key for the state of the Run session.
+type sessionKey struct{}
+
+// session is the state shared by a Run of the root router and the groups opened during it.
+type session struct {
+	done map[optionKey]bool // Once options that completed.
+}
+
+// withSession returns a context carrying the session state, keeping the one
+// of an enclosing Run, so that groups share the session of their root.
+func withSession(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(sessionKey{}).(*session); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, sessionKey{}, &session{done: map[optionKey]bool{}})
+}
+
+// alreadyDone reports whether the Once option completed in this session and prints
+// a message if it did. Outside of Run, e.g. in Exec, options are never done.
+func alreadyDone(ctx context.Context, option *Option) bool {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	if !option.Once || !ok || !s.done[optionKeyOf(ctx, option.id())] {
+		return false
+	}
+
+	_, _ = fmt.Fprintf(Out(ctx), "Already done: %s.\n", option.Name)
+
+	return true
+}
+
+// markDone records that the handler of the Once option ran and succeeded in this session.
+// It is called from the option itself, so declined confirmations and errors passed
+// to OnError don't count, and every way of running the option, such as search
+// and favorites, is covered.
+func markDone(ctx context.Context, option *Option) {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok && option.Once {
+		s.done[optionKeyOf(ctx, option.id())] = true
+	}
+}
@@ -0,0 +1,146 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOnceOption(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n"), &output),
+		WithOptions(Option{Name: "Initialize", Once: true, Handler: func(context.Context) error {
+			runs++
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Errorf("expected the handler to run once, got %d", runs)
+	}
+
+	if !strings.Contains(output.String(), "Already done: Initialize.") {
+		t.Errorf("repeated selection not reported:\n%s", output.String())
+	}
+
+	router.SetInputOutput(strings.NewReader("1\n0\n"), &output)
+	router.Run(t.Context())
+
+	if runs != 2 {
+		t.Errorf("expected the handler to run again in a new session, got %d runs", runs)
+	}
+}
+
+func TestOnceOptionNotDone(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+	handler := func(context.Context) error {
+		runs++
+		return nil
+	}
+
+	failures := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\nn\n1\ny\n1\n2\n2\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Initialize", Once: true, Confirm: "Initialize?", Handler: handler},
+			Option{
+				Name: "Migrate", Once: true,
+				Handler: func(context.Context) error { return errors.New("failed") },
+				OnError: func(context.Context, error) { failures++ },
+			},
+		),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Errorf("expected the handler to run after the confirmation was accepted, got %d runs", runs)
+	}
+
+	if strings.Count(output.String(), "Already done: Initialize.") != 1 {
+		t.Errorf("declined confirmation counted as done:\n%s", output.String())
+	}
+
+	if failures != 2 {
+		t.Errorf("failed option counted as done, %d failures", failures)
+	}
+}
+
+func TestOnceOptionSearch(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n//init\n1\n0\n"), &output),
+		WithOptions(Option{Name: "Initialize", Once: true, Handler: func(context.Context) error {
+			runs++
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Errorf("expected the handler to run once, got %d", runs)
+	}
+
+	if !strings.Contains(output.String(), "Already done: Initialize.") {
+		t.Errorf("repeated selection from search not reported:\n%s", output.String())
+	}
+}
+
+func TestOnceOptionFavorites(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("2\n1\n0\n1\n1\n0\n0\n"), &output),
+		WithFavoritesMenu(true),
+		WithOptions(Option{Name: "Initialize", Once: true, Handler: func(context.Context) error {
+			runs++
+			return nil
+		}}),
+	)
+	router.ToggleFavorite("initialize")
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Errorf("expected the handler to run once, got %d", runs)
+	}
+}
+
+func TestOnceOptionsWithSameName(t *testing.T) {
+	var output bytes.Buffer
+
+	var calls []string
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n1\n0\n2\n1\n0\n0\n"), &output),
+	)
+	for _, name := range []string{"Database", "Cache"} {
+		router.Group(name, Option{Name: "Init", Once: true, Handler: func(context.Context) error {
+			calls = append(calls, name)
+			return nil
+		}})
+	}
+
+	router.Run(t.Context())
+
+	if strings.Join(calls, ",") != "Database,Cache" {
+		t.Errorf("expected both Init options to run, ran %v:\n%s", calls, output.String())
+	}
+}
@@ -0,0 +1,50 @@
+package cmdrouter
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// ErrUnsupportedPlatform is returned by OpenExternal on systems without a known opener.
+var ErrUnsupportedPlatform = errors.New("unsupported platform")
+
+// startOpener starts the opener command without waiting for it.
+func startOpener(name string, args ...string) error {
+	cmd := exec.Command(name, args...) //nolint:noctx // the opener outlives the command
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	go func() { _ = cmd.Wait() }()
+
+	return nil
+}
+
+// OpenExternal opens the URL or file in the default application of the system,
+// e.g. for an "Open documentation" option. It uses xdg-open on Linux and BSDs,
+// open on macOS and the URL protocol handler on Windows, so the target isn't parsed
+// by a shell, and returns ErrUnsupportedPlatform elsewhere.
+func (c *CmdRouter) OpenExternal(target string) error {
+	var (
+		name string
+		args []string
+	)
+
+	switch c.goos {
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		name, args = "xdg-open", []string{target}
+	case "darwin":
+		name, args = "open", []string{target}
+	case "windows":
+		name, args = "rundll32", []string{"url.dll,FileProtocolHandler", target}
+	default:
+		return fmt.Errorf("open %q: %w %s", target, ErrUnsupportedPlatform, c.goos)
+	}
+
+	if err := c.startOpener(name, args...); err != nil {
+		return fmt.Errorf("open %q: %w", target, err)
+	}
+
+	return nil
+}
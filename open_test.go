@@ -0,0 +1,44 @@
+package cmdrouter
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestOpenExternal(t *testing.T) {
+	router := NewCmdRouter("Menu")
+
+	var command []string
+
+	router.startOpener = func(name string, args ...string) error {
+		command = append([]string{name}, args...)
+		return nil
+	}
+
+	router.goos = "linux"
+
+	if err := router.OpenExternal("https://go.dev"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(command, []string{"xdg-open", "https://go.dev"}) {
+		t.Errorf("opener command %q, want [xdg-open https://go.dev]", command)
+	}
+
+	router.goos = "windows"
+
+	if err := router.OpenExternal("https://go.dev/search?q=a&m=b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(command, []string{"rundll32", "url.dll,FileProtocolHandler", "https://go.dev/search?q=a&m=b"}) {
+		t.Errorf("opener command %q, want the URL passed as a single argument to rundll32", command)
+	}
+
+	router.goos = "plan9"
+
+	if err := router.OpenExternal("https://go.dev"); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("expected ErrUnsupportedPlatform, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package cmdrouter
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// optionIDKey is the context key for the ID of the running option.
+type optionIDKey struct{}
+
+// OptionID returns the stable ID of the running option, see Option.ID.
+// Outside of an option it returns an empty string.
+func OptionID(ctx context.Context) string {
+	id, _ := ctx.Value(optionIDKey{}).(string)
+	return id
+}
+
+// id returns the ID of the option, or the slug of its name if the ID is not set.
+func (o Option) id() string {
+	if o.ID != "" {
+		return o.ID
+	}
+
+	return slug(o.Name)
+}
+
+// slug converts the name into a lowercase identifier with words joined by "-",
+// e.g. "Admin Panel" into "admin-panel".
+func slug(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
+
+// optionKey identifies an option by the router it belongs to and its ID,
+// so that options with the same name in different groups are told apart.
+type optionKey struct {
+	router *CmdRouter
+	id     string
+}
+
+// optionKeyOf returns the key of the option with the ID running with the context.
+func optionKeyOf(ctx context.Context, id string) optionKey {
+	router, _ := ctx.Value(routerKey{}).(*CmdRouter)
+	return optionKey{router: router, id: id}
+}
@@ -0,0 +1,32 @@
+package cmdrouter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOptionID(t *testing.T) {
+	var ids []string
+
+	router := NewCmdRouter("Menu",
+		Option{Name: "Admin Panel", Handler: func(context.Context) error { return nil }},
+		Option{Name: "Log in", ID: "login", Handler: func(context.Context) error { return nil }},
+	)
+	router.AddMiddlewares(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			ids = append(ids, OptionID(ctx))
+			return next(ctx)
+		}
+	})
+
+	for _, name := range []string{"Admin Panel", "Log in"} {
+		if err := router.Invoke(t.Context(), name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if strings.Join(ids, ",") != "admin-panel,login" {
+		t.Errorf("ids = %v, want [admin-panel login]", ids)
+	}
+}
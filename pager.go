@@ -0,0 +1,115 @@
+package cmdrouter
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is the pager used if $PAGER is not set.
+const defaultPager = "less"
+
+// outKey is the context key for the writer returned by Out.
+type outKey struct{}
+
+// Out returns the writer the running command should print to: the output of its router,
+// or the pager if WithPager is enabled. Outside of a command it returns os.Stdout.
+func Out(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(outKey{}).(io.Writer); ok {
+		return w
+	}
+
+	if router, ok := ctx.Value(routerKey{}).(*CmdRouter); ok {
+		return router.out
+	}
+
+	return os.Stdout
+}
+
+// WithPager makes the output that commands print to Out(ctx) go through $PAGER
+// (less by default), e.g. for long logs. It only applies when the output is a terminal.
+// The pager starts on the first write and is killed if the command's context is canceled.
+func WithPager(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.pager = enable
+	}
+}
+
+// paged wraps the command handler so that its output goes through the pager if it is enabled.
+func (c *CmdRouter) paged(handler Handler) Handler {
+	if !c.pager || !c.terminalFunc(c.out) {
+		return handler
+	}
+
+	return func(ctx context.Context) error {
+		pager := &pagerWriter{ctx: ctx, out: c.out}
+
+		err := handler(context.WithValue(ctx, outKey{}, pager))
+		if pagerErr := pager.close(); pagerErr != nil {
+			Logger(ctx).Error("pager", "err", pagerErr)
+		}
+
+		return err
+	}
+}
+
+// pagerWriter pipes the written data to a pager process started on the first write.
+// If the pager can't be started, the data is written to out directly.
+type pagerWriter struct {
+	ctx   context.Context //nolint:containedctx // the pager is started lazily
+	out   io.Writer
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	err   error // Error starting the pager.
+}
+
+// Write implements io.Writer.
+func (p *pagerWriter) Write(b []byte) (int, error) {
+	if p.cmd == nil && p.err == nil {
+		p.err = p.start()
+	}
+
+	if p.err != nil {
+		return p.out.Write(b)
+	}
+
+	return p.stdin.Write(b)
+}
+
+// start starts the pager from $PAGER writing to out.
+func (p *pagerWriter) start() error {
+	command := strings.Fields(os.Getenv("PAGER"))
+	if len(command) == 0 {
+		command = []string{defaultPager}
+	}
+
+	cmd := exec.CommandContext(p.ctx, command[0], command[1:]...) //nolint:gosec // the pager is chosen by the user
+	cmd.Stdout = p.out
+	cmd.Stderr = p.out
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	p.cmd, p.stdin = cmd, stdin
+
+	return nil
+}
+
+// close waits for the pager to exit if it was started.
+func (p *pagerWriter) close() error {
+	if p.cmd == nil {
+		return nil
+	}
+
+	_ = p.stdin.Close()
+
+	return p.cmd.Wait()
+}
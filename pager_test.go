@@ -0,0 +1,39 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed is not available")
+	}
+
+	t.Setenv("PAGER", "sed s/^/paged:/")
+
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithPager(true),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.terminalFunc = func(any) bool { return true }
+	router.AddOptions(Option{Name: "Logs", Handler: func(ctx context.Context) error {
+		for i := range 3 {
+			_, _ = fmt.Fprintf(Out(ctx), "line %d\n", i)
+		}
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "paged:line 0\npaged:line 1\npaged:line 2\n") {
+		t.Errorf("handler output did not go through the pager:\n%s", output.String())
+	}
+}
@@ -0,0 +1,103 @@
+package cmdrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// preferencesFileMode is the permission of files written by SavePreferences.
+const preferencesFileMode = 0o600
+
+// Preferences are the settings of a router that users may want to keep between runs.
+type Preferences struct {
+	ShowPath       bool      `json:"showPath"`       // See WithPath.
+	ShowExit       bool      `json:"showExit"`       // See WithShowExit.
+	NameFirst      bool      `json:"nameFirst"`      // See WithNameFirst.
+	ShortcutColumn bool      `json:"shortcutColumn"` // See WithShortcutColumn.
+	FuzzyMatch     bool      `json:"fuzzyMatch"`     // See WithFuzzyMatch.
+	TypeAhead      bool      `json:"typeAhead"`      // See WithTypeAhead.
+	Sort           SortOrder `json:"sort"`           // See WithSort.
+	MaxWidth       int       `json:"maxWidth"`       // See WithMaxWidth.
+}
+
+// preferencesFile is the content of the file written by SavePreferences: the preferences
+// and the favorites, which are kept apart so that Preferences stays comparable.
+type preferencesFile struct {
+	Preferences
+
+	Favorites []string `json:"favorites"` // See ToggleFavorite.
+}
+
+// Preferences returns the current preferences of the router.
+func (c *CmdRouter) Preferences() Preferences {
+	return Preferences{
+		ShowPath:       c.pathShow,
+		ShowExit:       c.showExit,
+		NameFirst:      c.nameFirst,
+		ShortcutColumn: c.shortcutColumn,
+		FuzzyMatch:     c.fuzzyMatch,
+		TypeAhead:      c.typeAhead,
+		Sort:           c.sortOrder,
+		MaxWidth:       c.maxWidth,
+	}
+}
+
+// SetPreferences applies the preferences to the router and its groups.
+// Groups that set their own path display with SetPathShow keep it.
+func (c *CmdRouter) SetPreferences(p Preferences) {
+	c.PathShow(p.ShowPath)
+	c.setPreferences(p)
+}
+
+// setPreferences applies the preferences other than the path display to the router and its groups.
+func (c *CmdRouter) setPreferences(p Preferences) {
+	c.showExit = p.ShowExit
+	c.nameFirst = p.NameFirst
+	c.shortcutColumn = p.ShortcutColumn
+	c.fuzzyMatch = p.FuzzyMatch
+	c.typeAhead = p.TypeAhead
+	c.sortOrder = p.Sort
+	c.maxWidth = p.MaxWidth
+	c.sortOptions()
+
+	for _, option := range c.options {
+		if option.group != nil {
+			option.group.setPreferences(p)
+		}
+	}
+}
+
+// SavePreferences writes the preferences and the favorites of the router to the file at path as JSON.
+func (c *CmdRouter) SavePreferences(path string) error {
+	data, err := json.MarshalIndent(preferencesFile{Preferences: c.Preferences(), Favorites: c.Favorites()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode preferences: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, preferencesFileMode); err != nil {
+		return fmt.Errorf("save preferences: %w", err)
+	}
+
+	return nil
+}
+
+// LoadPreferences reads the preferences and the favorites saved by SavePreferences
+// from the file at path and applies them to the router and its groups.
+// Values missing from the file keep their current values.
+func (c *CmdRouter) LoadPreferences(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("load preferences: %w", err)
+	}
+
+	file := preferencesFile{Preferences: c.Preferences(), Favorites: c.Favorites()}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("decode preferences: %w", err)
+	}
+
+	c.SetPreferences(file.Preferences)
+	c.store.Set(favoritesKey, file.Favorites)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndLoadPreferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefs.json")
+
+	saved := NewCmdRouterWithSettings("Menu", WithNameFirst(true), WithSort(SortByName))
+	saved.ToggleFavorite("a")
+
+	if err := saved.SavePreferences(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("0\n"), &output),
+		WithOptions(Option{Name: "B"}, Option{Name: "A"}),
+	)
+	router.Group("C")
+
+	if err := router.LoadPreferences(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := router.Preferences(); got != saved.Preferences() {
+		t.Errorf("loaded preferences %+v, want %+v", got, saved.Preferences())
+	}
+
+	if got := router.Favorites(); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("loaded favorites %v, want [a]", got)
+	}
+
+	if got := router.options[2].group.Preferences(); !got.NameFirst {
+		t.Errorf("preferences not applied to the group: %+v", got)
+	}
+
+	router.Run(t.Context())
+
+	if !strings.Contains(output.String(), "| A    | 1 |\n| B    | 2 |\n| C    | 3 |\n") {
+		t.Errorf("preferences not applied to the menu:\n%s", output.String())
+	}
+}
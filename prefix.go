@@ -0,0 +1,41 @@
+package cmdrouter
+
+import (
+	"context"
+	"strings"
+)
+
+// WithPrefixMatch enables or disables selecting options by typing the start of their names,
+// e.g. "adm" for "Admin Panel". The match is case-insensitive and must be unique;
+// an exact name match always wins. If several names start with the input,
+// they are listed with their numbers so the user can pick one.
+func WithPrefixMatch(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.prefixMatch = enable
+	}
+}
+
+// prefixMatches returns the numbers of the options whose names start with the input,
+// in menu order. It returns nil if prefix matching is disabled.
+func (c *CmdRouter) prefixMatches(ctx context.Context, input string) []int {
+	if !c.prefixMatch || input == "" {
+		return nil
+	}
+
+	var numbers []int
+
+	prefix := strings.ToLower(input)
+
+	for i, option := range c.options {
+		name := option.displayName(ctx)
+		if strings.EqualFold(name, input) {
+			return []int{i + 1}
+		}
+
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			numbers = append(numbers, i+1)
+		}
+	}
+
+	return numbers
+}
@@ -0,0 +1,41 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPrefixMatch(t *testing.T) {
+	var output bytes.Buffer
+
+	var ran []string
+
+	handler := func(name string) Handler {
+		return func(context.Context) error {
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithPrefixMatch(true),
+		WithInputOutput(strings.NewReader("Adm\nLo\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Admin Panel", Handler: handler("Admin Panel")},
+			Option{Name: "Login", Handler: handler("Login")},
+			Option{Name: "Logout", Handler: handler("Logout")},
+		),
+	)
+
+	router.Run(t.Context())
+
+	if strings.Join(ran, ",") != "Admin Panel" {
+		t.Errorf("ran %v, want [Admin Panel]", ran)
+	}
+
+	if !strings.Contains(output.String(), `Ambiguous prefix "Lo": 2) Login, 3) Logout.`) {
+		t.Errorf("ambiguous prefix not reported:\n%s", output.String())
+	}
+}
@@ -0,0 +1,197 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Key codes handled by lineEditor.
+const (
+	keyEOF       = 0x04
+	keyBackspace = 0x08
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// WithReadline enables or disables line editing with history at the prompt.
+func WithReadline(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.Readline(enable)
+	}
+}
+
+// Readline enables or disables line editing at the prompt for the current router and its groups.
+// When enabled and both input and output are terminals, the arrow keys up and down
+// recall the previous entries of the session. Otherwise the input is read as usual.
+func (c *CmdRouter) Readline(enable bool) {
+	c.readline = enable
+	c.setupEditor()
+}
+
+// setupEditor creates the prompt line editor if readline or instant keys are enabled
+// and the input and output streams support it.
+func (c *CmdRouter) setupEditor() {
+	c.editor, c.keyEditor = nil, nil
+
+	if (!c.readline && !c.instantKeys) || !isTerminal(c.in) || !isTerminal(c.out) {
+		return
+	}
+
+	in := c.in.(*os.File) //nolint:forcetypeassert // checked by isTerminal
+
+	restore, err := enableCbreak(in.Fd())
+	if err != nil {
+		return
+	}
+	restore()
+
+	editor := &lineEditor{in: in, out: c.out}
+	c.keyEditor = editor
+	c.editor = &lineReader{
+		next: editor.readLine,
+		raw: func() (func(), error) {
+			return enableCbreak(in.Fd())
+		},
+	}
+}
+
+// promptInput returns the reader used for the option number prompt.
+func (c *CmdRouter) promptInput() *lineReader {
+	if c.keyEditor != nil {
+		c.keyEditor.instant = nil
+	}
+
+	if c.editor != nil {
+		return c.editor
+	}
+
+	return c.input
+}
+
+// lineEditor reads a line key by key from a terminal in cbreak mode, echoing it back.
+// The arrow keys up and down navigate through the previously entered lines.
+type lineEditor struct {
+	in      io.Reader
+	out     io.Writer
+	history []string
+	instant func(key string) bool // Reports whether a key typed first completes the line, nil to wait for Enter.
+}
+
+// readLine reads and returns the next line without the trailing newline.
+func (e *lineEditor) readLine() (string, error) {
+	var (
+		line    []rune
+		pending []byte // Bytes of an incomplete UTF-8 sequence.
+		escape  []byte // Bytes of an incomplete escape sequence.
+	)
+
+	index := len(e.history)
+	key := make([]byte, 1)
+
+	for {
+		if _, err := io.ReadFull(e.in, key); err != nil {
+			if len(line) > 0 {
+				return string(line), nil
+			}
+			return "", io.EOF
+		}
+
+		b := key[0]
+
+		if len(escape) > 0 {
+			escape = append(escape, b)
+			if len(escape) < len("\x1b[A") {
+				continue
+			}
+
+			switch string(escape) {
+			case "\x1b[A":
+				if index > 0 {
+					index--
+					line = e.replace(line, e.history[index])
+				}
+			case "\x1b[B":
+				if index < len(e.history) {
+					index++
+					line = e.replace(line, e.historyAt(index))
+				}
+			}
+
+			escape = escape[:0]
+			continue
+		}
+
+		switch b {
+		case '\r', '\n':
+			return e.finish(line), nil
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				e.erase(string(line[len(line)-1]))
+				line = line[:len(line)-1]
+			}
+		case keyEOF:
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+		case keyEscape:
+			escape = append(escape, b)
+		default:
+			if b < ' ' {
+				continue
+			}
+
+			pending = append(pending, b)
+			if !utf8.FullRune(pending) {
+				continue
+			}
+
+			r, _ := utf8.DecodeRune(pending)
+			pending = pending[:0]
+
+			line = append(line, r)
+			_, _ = fmt.Fprint(e.out, string(r))
+
+			if len(line) == 1 && e.instant != nil && e.instant(string(r)) {
+				return e.finish(line), nil
+			}
+		}
+	}
+}
+
+// finish ends the line on the screen, adds it to the history and returns it.
+func (e *lineEditor) finish(line []rune) string {
+	_, _ = fmt.Fprintln(e.out)
+	if len(line) > 0 {
+		e.history = append(e.history, string(line))
+	}
+
+	return string(line)
+}
+
+// historyAt returns the history entry at index, or an empty line past the end.
+func (e *lineEditor) historyAt(index int) string {
+	if index < len(e.history) {
+		return e.history[index]
+	}
+
+	return ""
+}
+
+// replace erases the current line on the screen and prints the new one.
+func (e *lineEditor) replace(line []rune, newLine string) []rune {
+	e.erase(string(line))
+	_, _ = fmt.Fprint(e.out, newLine)
+
+	return []rune(newLine)
+}
+
+// erase removes the text from the end of the current screen line.
+func (e *lineEditor) erase(text string) {
+	width := displayWidth(text)
+	back := strings.Repeat("\b", width)
+
+	_, _ = fmt.Fprint(e.out, back+strings.Repeat(" ", width)+back)
+}
@@ -0,0 +1,87 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadlineFallback(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	executed := false
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithReadline(true),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Option", Handler: func(_ context.Context) error {
+		executed = true
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if router.editor != nil {
+		t.Error("Line editor is used for non-terminal input")
+	}
+
+	if !executed {
+		t.Error("Handler was not executed")
+	}
+}
+
+func TestLineEditor(t *testing.T) {
+	var output bytes.Buffer
+
+	editor := &lineEditor{
+		in:  strings.NewReader("12\x7f3\n7\n\x1b[A\x1b[A\n\x1b[A\x1b[A\x1b[B\x1b[B\x1b[B\n"),
+		out: &output,
+	}
+
+	expected := []string{"13", "7", "13", ""}
+	for _, want := range expected {
+		line, err := editor.readLine()
+		if err != nil || line != want {
+			t.Errorf("expected %q, got %q (%v)", want, line, err)
+		}
+	}
+
+	if _, err := editor.readLine(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestLineReaderRestoresOnCancel(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	raw := false
+
+	reader := &lineReader{
+		next: func() (string, error) {
+			<-block
+			return "", io.EOF
+		},
+		raw: func() (func(), error) {
+			raw = true
+			return func() { raw = false }, nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := reader.readLine(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if raw {
+		t.Error("terminal mode not restored while the read is still blocked")
+	}
+}
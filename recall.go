@@ -0,0 +1,37 @@
+package cmdrouter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// defaultRecallKey is the input selecting the last selected option again.
+const defaultRecallKey = "r"
+
+// WithRecallKey sets the input that runs the option run last without an error in the menu again
+// ("r" by default), so that the user doesn't have to look up its number.
+// Option numbers and hotkeys take precedence. An empty key disables the recall.
+func WithRecallKey(key string) Setting {
+	return func(c *CmdRouter) {
+		c.recallKey = key
+	}
+}
+
+// isRecallInput reports whether the input is the recall key.
+func (c *CmdRouter) isRecallInput(input string) bool {
+	return c.recallKey != "" && strings.EqualFold(input, c.recallKey)
+}
+
+// recall returns the number of the option run last without an error in this menu,
+// found by its ID, so that it is the same option even if the menu changed since.
+// If there is no such option, it prints a message and reports false.
+func (c *CmdRouter) recall() ([]int, bool) {
+	i := slices.IndexFunc(c.options, func(o Option) bool { return o.id() == c.lastRun })
+	if c.lastRun == "" || i < 0 {
+		_, _ = fmt.Fprintln(c.out, "Nothing to repeat yet.")
+		return nil, false
+	}
+
+	return []int{i + 1}, true
+}
@@ -0,0 +1,65 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecallLastSelection(t *testing.T) {
+	var output bytes.Buffer
+
+	runs := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("r\n2\nr\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Help", Handler: func(context.Context) error { return nil }},
+			Option{Name: "Sync", Handler: func(context.Context) error {
+				runs++
+				return nil
+			}},
+		),
+	)
+
+	router.Run(t.Context())
+
+	if runs != 2 {
+		t.Errorf("Sync ran %d times, want 2", runs)
+	}
+
+	if !strings.Contains(output.String(), "Nothing to repeat yet.") {
+		t.Errorf("recall without a previous selection not reported:\n%s", output.String())
+	}
+}
+
+func TestRecallByIdentity(t *testing.T) {
+	var output bytes.Buffer
+
+	syncs, failures := 0, 0
+
+	var router *CmdRouter
+	router = NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("2\n2\nr\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Help", Handler: func(context.Context) error { return nil }},
+			Option{Name: "Sync", Handler: func(context.Context) error {
+				syncs++
+				router.RemoveOption("Help")
+				return nil
+			}},
+			Option{Name: "Fail", Handler: func(context.Context) error {
+				failures++
+				return errors.New("failed")
+			}},
+		),
+	)
+
+	router.Run(t.Context())
+
+	if syncs != 2 || failures != 1 {
+		t.Errorf("Sync ran %d times and Fail %d times, want the recall to run Sync again", syncs, failures)
+	}
+}
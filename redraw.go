@@ -0,0 +1,33 @@
+package cmdrouter
+
+import "context"
+
+// redrawKey is the context key for the redrawSlot of the running command.
+type redrawKey struct{}
+
+// redrawSlot records whether the running command asked to skip the menu redraw.
+type redrawSlot struct {
+	suppressed bool
+}
+
+// SuppressRedraw tells the router not to redraw the menu after the running command,
+// e.g. when the handler runs its own interactive loop and leaves the screen as the user
+// should see it. The next prompt is shown without the menu and the blank line after
+// the command output. Outside of a command run by the menu it does nothing.
+func SuppressRedraw(ctx context.Context) {
+	if slot, ok := ctx.Value(redrawKey{}).(*redrawSlot); ok {
+		slot.suppressed = true
+	}
+}
+
+// redraw shows the path and the menu, unless the last command suppressed it.
+func (c *CmdRouter) redraw(ctx context.Context) {
+	if c.skipRedraw {
+		c.skipRedraw = false
+		return
+	}
+
+	c.showPath()
+	c.showSubtitle()
+	c.showMenu(ctx)
+}
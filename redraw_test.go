@@ -0,0 +1,34 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSuppressRedraw(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n2\n0\n"), &output),
+	)
+	router.AddOptions(
+		Option{Name: "Wizard", Handler: func(ctx context.Context) error {
+			SuppressRedraw(ctx)
+			return nil
+		}},
+		Option{Name: "Status", Handler: func(_ context.Context) error { return nil }},
+	)
+
+	router.Run(ctx)
+
+	if menus := strings.Count(output.String(), "| # | Menu   |"); menus != 2 {
+		t.Errorf("expected the menu to be skipped once after SuppressRedraw, got %d menus:\n%s", menus, output.String())
+	}
+
+	if prompts := strings.Count(output.String(), "Enter option number"); prompts != 3 {
+		t.Errorf("expected 3 prompts, got %d", prompts)
+	}
+}
@@ -0,0 +1,63 @@
+package cmdrouter
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// RegisterStruct registers every exported method of v with the signature
+// func(context.Context) error as an option. Option names are derived from
+// the method names, e.g. "ViewProfile" becomes "View Profile".
+// Methods are registered in lexicographic order of their names.
+//
+// Use a pointer to register methods with pointer receivers. A nil v registers nothing.
+func (c *CmdRouter) RegisterStruct(v any) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return
+	}
+
+	handlerType := reflect.TypeFor[func(context.Context) error]()
+
+	for i := range value.NumMethod() {
+		method := value.Method(i)
+		if method.Type() != handlerType {
+			continue
+		}
+
+		handler, ok := method.Interface().(func(context.Context) error)
+		if !ok {
+			continue
+		}
+
+		c.AddOptions(Option{
+			Name:    splitCamelCase(value.Type().Method(i).Name),
+			Handler: handler,
+		})
+	}
+}
+
+// splitCamelCase inserts spaces between the words of a CamelCase identifier,
+// keeping acronyms together, e.g. "ShowHTTPStatus" -> "Show HTTP Status".
+func splitCamelCase(name string) string {
+	runes := []rune(name)
+
+	var result strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if prevLower || nextLower {
+				result.WriteByte(' ')
+			}
+		}
+
+		result.WriteRune(r)
+	}
+
+	return result.String()
+}
@@ -0,0 +1,77 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type accountService struct {
+	calls []string
+}
+
+func (s *accountService) Login(_ context.Context) error {
+	s.calls = append(s.calls, "login")
+	return nil
+}
+
+func (s *accountService) ViewProfile(_ context.Context) error {
+	s.calls = append(s.calls, "profile")
+	return nil
+}
+
+func (s *accountService) Name() string {
+	return "not a handler"
+}
+
+func TestRegisterStruct(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	service := &accountService{}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMultiSelect(true),
+		WithInputOutput(strings.NewReader("1,2\n0\n"), &output),
+	)
+	router.RegisterStruct(service)
+
+	if len(router.options) != 2 {
+		t.Fatalf("expected 2 options, got %d", len(router.options))
+	}
+
+	if router.options[0].Name != "Login" || router.options[1].Name != "View Profile" {
+		t.Errorf("unexpected option names: %q, %q", router.options[0].Name, router.options[1].Name)
+	}
+
+	router.Run(ctx)
+
+	if strings.Join(service.calls, ",") != "login,profile" {
+		t.Errorf("unexpected calls: %v", service.calls)
+	}
+}
+
+func TestSplitCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"Login":          "Login",
+		"ViewProfile":    "View Profile",
+		"ShowHTTPStatus": "Show HTTP Status",
+		"ExportCSV":      "Export CSV",
+	}
+
+	for input, expected := range cases {
+		if got := splitCamelCase(input); got != expected {
+			t.Errorf("splitCamelCase(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestRegisterStructNil(t *testing.T) {
+	router := NewCmdRouter("Menu")
+	router.RegisterStruct(nil)
+
+	if len(router.options) != 0 {
+		t.Errorf("expected no options, got %d", len(router.options))
+	}
+}
@@ -0,0 +1,28 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRepeat can be returned by a command handler to run the command again
+// instead of returning to the menu, e.g. for entering several records in a row.
+// The command runs again with its middlewares until it returns nil or another error,
+// or the context is done. Outside of the menu, e.g. with Exec, it is returned as is.
+var ErrRepeat = errors.New("repeat")
+
+// repeating wraps the command handler so that it runs again while it returns ErrRepeat.
+func repeating(handler Handler) Handler {
+	return func(ctx context.Context) error {
+		for {
+			err := handler(ctx)
+			if !errors.Is(err, ErrRepeat) {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRepeat(t *testing.T) {
+	var output bytes.Buffer
+
+	calls := 0
+	middlewareCalls := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+		WithMiddlewares(func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				middlewareCalls++
+				return next(ctx)
+			}
+		}),
+		WithOptions(Option{Name: "Add record", Handler: func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return ErrRepeat
+			}
+
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if calls != 3 || middlewareCalls != 3 {
+		t.Errorf("handler ran %d times and middleware %d times, want 3", calls, middlewareCalls)
+	}
+
+	if n := strings.Count(output.String(), "Enter option number"); n != 2 {
+		t.Errorf("menu prompted %d times, want 2:\n%s", n, output.String())
+	}
+}
+
+func TestRepeatFromSearch(t *testing.T) {
+	var output bytes.Buffer
+
+	calls := 0
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithShowDuration(true),
+		WithInputOutput(strings.NewReader("//record\n1\n0\n"), &output),
+	)
+	router.Group("Data", Option{Name: "Add record", Handler: func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return ErrRepeat
+		}
+
+		return nil
+	}})
+
+	router.Run(t.Context())
+
+	if calls != 3 {
+		t.Errorf("handler ran %d times from search, want 3", calls)
+	}
+
+	if n := strings.Count(output.String(), "(took "); n != 3 {
+		t.Errorf("duration printed %d times, want 3:\n%s", n, output.String())
+	}
+}
@@ -0,0 +1,76 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by ExitCode.
+const (
+	ExitOK          = 0 // The command succeeded.
+	ExitFailure     = 1 // The command failed.
+	ExitUnknownPath = 2 // The arguments don't lead to a command.
+)
+
+// ExitCodeError is returned by handlers to choose the exit code reported by ExitCode,
+// e.g. return &cmdrouter.ExitCodeError{Code: 3, Err: err}.
+type ExitCodeError struct {
+	Code int
+	Err  error // Underlying error, may be nil.
+}
+
+// Error implements the error interface.
+func (e *ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// RunArgs runs the command given by command-line arguments without showing the menu,
+// for scripted use: the names of the groups leading to the command and its own name,
+// followed by the arguments for its ArgHandler, e.g. ["Users", "Add", "alice"].
+// The command runs with the same middlewares as if the user had navigated to it.
+// If the arguments don't lead to a command, the error wraps ErrUnknownPath.
+// The returned error can be turned into a process exit code with ExitCode.
+func (c *CmdRouter) RunArgs(ctx context.Context, args []string) error {
+	for i := 1; i <= len(args); i++ {
+		if target, err := c.resolve(args[:i]); err == nil {
+			_, err = target.exec(withArgs(ctx, args[i:]))
+			return err
+		}
+	}
+
+	_, err := c.resolve(args)
+
+	return err
+}
+
+// ExitCode maps the error returned by RunArgs to a process exit code:
+// ExitOK for nil, the code of an ExitCodeError, ExitUnknownPath for errors
+// wrapping ErrUnknownPath and ExitFailure for any other error, so that main can do
+//
+//	os.Exit(cmdrouter.ExitCode(router.RunArgs(ctx, os.Args[1:])))
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var codeErr *ExitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+
+	if errors.Is(err, ErrUnknownPath) {
+		return ExitUnknownPath
+	}
+
+	return ExitFailure
+}
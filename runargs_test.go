@@ -0,0 +1,51 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRunArgsExitCodes(t *testing.T) {
+	ctx := t.Context()
+	errFailed := errors.New("failed")
+
+	var received []string
+
+	router := NewCmdRouter("Menu")
+	router.Group("Users",
+		Option{Name: "Add", ArgHandler: func(_ context.Context, args []string) error {
+			received = args
+			return nil
+		}},
+		Option{Name: "Remove", Handler: func(_ context.Context) error {
+			return errFailed
+		}},
+		Option{Name: "Purge", Handler: func(_ context.Context) error {
+			return &ExitCodeError{Code: 3, Err: errFailed}
+		}},
+	)
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"Users", "Add", "alice"}, ExitOK},
+		{[]string{"Users", "Remove"}, ExitFailure},
+		{[]string{"Users", "Purge"}, 3},
+		{[]string{"Users", "Rename"}, ExitUnknownPath},
+		{[]string{"Users"}, ExitUnknownPath},
+		{nil, ExitUnknownPath},
+	}
+
+	for _, tt := range tests {
+		if code := ExitCode(router.RunArgs(ctx, tt.args)); code != tt.code {
+			t.Errorf("%v: expected exit code %d, got %d", tt.args, tt.code, code)
+		}
+	}
+
+	if !slices.Equal(received, []string{"alice"}) {
+		t.Errorf("expected the arguments [alice], got %v", received)
+	}
+}
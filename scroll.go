@@ -0,0 +1,102 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	scrollRight = ">" // Input scrolling a clipped menu to the right.
+	scrollLeft  = "<" // Input scrolling a clipped menu to the left.
+)
+
+// WithMaxWidth clips the menu to the given number of columns instead of fitting it
+// into the terminal. Clipped content is marked with "→" and "←", and the user can
+// scroll the menu horizontally by entering ">" and "<". A width <= 0 disables clipping.
+func WithMaxWidth(width int) Setting {
+	return func(c *CmdRouter) {
+		c.maxWidth = width
+	}
+}
+
+// printClipped prints the table clipped to the maximum width at the current scroll offset.
+func (c *CmdRouter) printClipped(out io.Writer, headers []string, rows [][]any) {
+	var table bytes.Buffer
+	c.tablePrinter.PrintTable(&table, headers, rows)
+
+	lines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+
+	c.tableWidth = 0
+	for _, line := range lines {
+		c.tableWidth = max(c.tableWidth, displayWidth(line))
+	}
+
+	c.scrollOffset = max(min(c.scrollOffset, c.tableWidth-c.maxWidth), 0)
+
+	for _, line := range lines {
+		_, _ = fmt.Fprintln(out, clipLine(line, c.scrollOffset, c.maxWidth))
+	}
+}
+
+// scroll moves the clipped menu horizontally by half of the maximum width.
+// It reports false if the input isn't a scroll command or the menu isn't clipped.
+func (c *CmdRouter) scroll(input string) bool {
+	if c.maxWidth <= 0 || c.tableWidth <= c.maxWidth {
+		return false
+	}
+
+	step := max(c.maxWidth/2, 1) //nolint:mnd // half of the width
+
+	switch input {
+	case scrollRight:
+		c.scrollOffset = min(c.scrollOffset+step, c.tableWidth-c.maxWidth)
+	case scrollLeft:
+		c.scrollOffset = max(c.scrollOffset-step, 0)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// clipLine returns the part of the line that is visible from the given column offset
+// within width columns. Clipped content on each side is marked with an arrow.
+func clipLine(line string, offset, width int) string {
+	left := offset > 0
+	right := displayWidth(line) > offset+width
+
+	start, available := offset, width
+	if left {
+		start++
+		available--
+	}
+
+	if right {
+		available--
+	}
+
+	var b strings.Builder
+
+	if left {
+		b.WriteString("←")
+	}
+
+	column, written := 0, 0
+
+	for _, r := range line {
+		w := runeWidth(r)
+		if column >= start && written+w <= available {
+			b.WriteRune(r)
+			written += w
+		}
+		column += w
+	}
+
+	if right {
+		b.WriteString(strings.Repeat(" ", available-written) + "→")
+	}
+
+	return b.String()
+}
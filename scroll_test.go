@@ -0,0 +1,48 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMaxWidthScrolling(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	const width = 20
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithMaxWidth(width),
+		WithOptions(Option{Name: "A very wide option name that is clipped"}),
+		WithInputOutput(strings.NewReader(">\n>\n>\n>\n<\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	menus := strings.Split(output.String(), "Enter option number: ")
+
+	if !strings.Contains(menus[0], "| 1 | A very wide o→") {
+		t.Errorf("first menu is not clipped with an indicator:\n%s", menus[0])
+	}
+
+	if !strings.Contains(menus[1], "←y wide option name→") {
+		t.Errorf("menu is not scrolled to the right:\n%s", menus[1])
+	}
+
+	if !strings.Contains(menus[3], "←e that is clipped |") || !strings.Contains(menus[4], "←e that is clipped |") {
+		t.Errorf("scrolling doesn't stop at the end of the menu:\n%s", menus[4])
+	}
+
+	if !strings.Contains(menus[5], "←option name that i→") {
+		t.Errorf("menu is not scrolled back to the left:\n%s", menus[5])
+	}
+
+	for _, line := range strings.Split(output.String(), "\n") {
+		if strings.HasPrefix(line, "|") || strings.HasPrefix(line, "+") || strings.HasPrefix(line, "←") {
+			if displayWidth(line) > width {
+				t.Errorf("line %q exceeds width %d", line, width)
+			}
+		}
+	}
+}
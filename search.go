@@ -0,0 +1,73 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// searchPrefix starts a search query across the whole menu tree, e.g. "//logs".
+const searchPrefix = "//"
+
+// search returns all commands in this router and its groups
+// whose names contain the query (case-insensitive).
+func (c *CmdRouter) search(query string) []treeOption {
+	query = strings.ToLower(query)
+
+	var results []treeOption
+
+	c.walkTree(func(found treeOption) {
+		if strings.Contains(strings.ToLower(found.option.Name), query) {
+			results = append(results, found)
+		}
+	})
+
+	return results
+}
+
+// runSearch prints the commands matching the query and runs the one chosen by the user.
+func (c *CmdRouter) runSearch(ctx context.Context, query string) {
+	results := c.search(query)
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(c.out, "No matches for %q.\n\n", query)
+		return
+	}
+
+	headers := []string{"#", "Search: " + query}
+	rows := make([][]any, 0, len(results)+1)
+
+	for i, result := range results {
+		rows = append(rows, []any{i + 1, strings.Join(result.path, " > ")})
+	}
+	rows = append(rows, []any{0, "<-Back"})
+
+	c.printTable(headers, rows, -1)
+	_, _ = fmt.Fprintln(c.out)
+
+	for {
+		_, _ = fmt.Fprint(c.out, "Enter result number: ")
+
+		line, err := c.promptInput().readLine(ctx)
+		if err != nil {
+			return
+		}
+
+		number, err := strconv.Atoi(strings.TrimSpace(line))
+		if err == nil && number == 0 {
+			return
+		}
+
+		if err == nil && number > 0 && number <= len(results) {
+			c.runSearchResult(ctx, results[number-1])
+			return
+		}
+
+		_, _ = fmt.Fprintln(c.out, "Invalid number. Try again.")
+	}
+}
+
+// runSearchResult runs the found command as if the user had navigated to it.
+func (c *CmdRouter) runSearchResult(ctx context.Context, result treeOption) {
+	_ = c.run(ctx, result.router, result.option, result.fullPath(), result.handler())
+}
@@ -0,0 +1,51 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGlobalSearch(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	callOrder := []string{}
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				callOrder = append(callOrder, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	router := NewCmdRouterWithSettings("Main",
+		WithMiddlewares(record("root")),
+		WithInputOutput(strings.NewReader("//backend\n1\n0\n"), &output),
+	)
+	router.AddOptions(Option{Name: "Login", Handler: func(_ context.Context) error {
+		callOrder = append(callOrder, "login")
+		return nil
+	}})
+
+	dev := router.Group("Developer")
+	dev.AddMiddlewares(record("dev"))
+	dev.Group("Logs", Option{Name: "Backend logs", Handler: func(_ context.Context) error {
+		callOrder = append(callOrder, "backend")
+		return nil
+	}})
+
+	router.Run(ctx)
+
+	if !strings.Contains(output.String(), "Main > Developer > Logs > Backend logs") {
+		t.Error("Search result with full path not printed")
+	}
+
+	expectedOrder := []string{"root", "dev", "backend"}
+	if strings.Join(callOrder, ",") != strings.Join(expectedOrder, ",") {
+		t.Errorf("expected call order %v, got %v", expectedOrder, callOrder)
+	}
+}
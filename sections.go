@@ -0,0 +1,22 @@
+package cmdrouter
+
+// WithAutoSections enables or disables grouping the options of the menu by their Category.
+// Categories are shown in name order under non-selectable header rows, with uncategorized
+// options at the top. Options are numbered continuously in the order they are shown.
+func WithAutoSections(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.autoSections = enable
+		c.sortOptions()
+	}
+}
+
+// sectionHeader returns the header row shown before the option with the given index,
+// or nil if the option doesn't start a new section.
+func (c *CmdRouter) sectionHeader(index int) []any {
+	category := c.options[index].Category
+	if !c.autoSections || category == "" || index > 0 && c.options[index-1].Category == category {
+		return nil
+	}
+
+	return c.menuRow("", "-- "+category+" --")
+}
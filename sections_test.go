@@ -0,0 +1,48 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAutoSections(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	option := func(name, category string) Option {
+		return Option{Name: name, Category: category, Handler: func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(option("Logs", "System"), option("Login", "Account"), option("Help", "")),
+		WithAutoSections(true),
+		WithInputOutput(strings.NewReader("2\n4\n0\n"), &output),
+	)
+	router.AddOptions(option("Logout", "Account"))
+
+	router.Run(ctx)
+
+	menu := strings.Join([]string{
+		"| 1 | Help          |",
+		"|   | -- Account -- |",
+		"| 2 | Login         |",
+		"| 3 | Logout        |",
+		"|   | -- System --  |",
+		"| 4 | Logs          |",
+		"| 0 | Exit          |",
+	}, "\n")
+	if !strings.Contains(output.String(), menu) {
+		t.Errorf("menu is not grouped by category:\n%s", output.String())
+	}
+
+	if strings.Join(executed, ",") != "Login,Logs" {
+		t.Errorf("selection doesn't match the shown numbers: %v", executed)
+	}
+}
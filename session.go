@@ -0,0 +1,33 @@
+package cmdrouter
+
+import "io"
+
+// RecordSession writes every line read from the input of the router and its groups to w,
+// one per line, including the input read by handlers, e.g. to reproduce a bug report
+// with ReplaySession. Recording continues when the input streams are replaced.
+// A nil writer stops recording.
+func (c *CmdRouter) RecordSession(w io.Writer) {
+	c.recorder = w
+	c.applyRecorder()
+
+	for _, option := range c.options {
+		if option.group != nil {
+			option.group.RecordSession(w)
+		}
+	}
+}
+
+// ReplaySession makes the router and its groups read the input recorded by RecordSession
+// from r, so that the next Run repeats the recorded session.
+func (c *CmdRouter) ReplaySession(r io.Reader) {
+	c.SetTreeInputOutput(r, c.out)
+}
+
+// applyRecorder makes the line readers of the router record the session if it is recorded.
+func (c *CmdRouter) applyRecorder() {
+	c.input.record = c.recorder
+
+	if c.editor != nil {
+		c.editor.record = c.recorder
+	}
+}
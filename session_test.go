@@ -0,0 +1,48 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplaySession(t *testing.T) {
+	ctx := t.Context()
+
+	newRouter := func(executed *[]string) *CmdRouter {
+		router := NewCmdRouter("Menu")
+		router.AddOptions(Option{Name: "Rename", Handler: func(ctx context.Context) error {
+			name, err := router.ReadBlock(ctx, "New name: ", "")
+			*executed = append(*executed, "rename:"+name)
+			return err
+		}})
+		router.Group("Settings", Option{Name: "Reset", Handler: func(_ context.Context) error {
+			*executed = append(*executed, "reset")
+			return nil
+		}})
+
+		return router
+	}
+
+	var recorded, replayed []string
+	var session, output bytes.Buffer
+
+	recorder := newRouter(&recorded)
+	recorder.SetTreeInputOutput(strings.NewReader("1\nbob\n.\nx\n2\n1\n0\n0\n"), &output)
+	recorder.RecordSession(&session)
+	recorder.Run(ctx)
+
+	if session.String() != "1\nbob\n.\nx\n2\n1\n0\n0\n" {
+		t.Errorf("unexpected recorded session %q", session.String())
+	}
+
+	player := newRouter(&replayed)
+	player.SetTreeInputOutput(strings.NewReader(""), &output)
+	player.ReplaySession(&session)
+	player.Run(ctx)
+
+	if strings.Join(recorded, ",") != "rename:bob,reset" || strings.Join(replayed, ",") != strings.Join(recorded, ",") {
+		t.Errorf("replayed %v, recorded %v", replayed, recorded)
+	}
+}
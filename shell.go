@@ -0,0 +1,97 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrCommandNotFound is returned by the handler of a ShellOption whose command doesn't exist.
+var ErrCommandNotFound = errors.New("command not found")
+
+// Exit codes of the shells for commands that don't exist.
+const (
+	shNotFound  = 127
+	cmdNotFound = 9009
+)
+
+// shellCommand returns the shell and its arguments running the command line on goos.
+func shellCommand(goos, command string) (string, []string) {
+	if goos == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+
+	return "sh", []string{"-c", command}
+}
+
+// ShellOption returns an option running the command line in the system shell
+// (sh -c, or cmd /C on Windows), e.g. ShellOption("Build", "make build").
+// The command writes to the router output, see Out, and reads the router input
+// if it is a file such as os.Stdin; it gets no input otherwise, so it can't consume
+// the input of the menu. The process is killed if the context is canceled.
+// A failing command returns its *exec.ExitError, and a command that doesn't exist
+// returns an error wrapping ErrCommandNotFound.
+func ShellOption(name, command string) Option {
+	return Option{Name: name, Handler: func(ctx context.Context) error {
+		goos := runtime.GOOS
+		if router, ok := ctx.Value(routerKey{}).(*CmdRouter); ok {
+			goos = router.goos
+		}
+
+		shell, args := shellCommand(goos, command)
+
+		out := Out(ctx)
+
+		cmd := exec.CommandContext(ctx, shell, args...)
+		cmd.Stdin = shellInput(ctx)
+		cmd.Stdout, cmd.Stderr = out, out
+
+		err := cmd.Run()
+
+		if isNotFound(shell, err) {
+			return fmt.Errorf("run %q: %w", command, ErrCommandNotFound)
+		}
+
+		if err != nil {
+			return fmt.Errorf("run %q: %w", command, err)
+		}
+
+		return nil
+	}}
+}
+
+// isNotFound reports whether the shell failed because the command or the shell itself doesn't exist.
+func isNotFound(shell string, err error) bool {
+	if errors.Is(err, exec.ErrNotFound) {
+		return true
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	if shell == "cmd" {
+		return exitErr.ExitCode() == cmdNotFound
+	}
+
+	return exitErr.ExitCode() == shNotFound
+}
+
+// shellInput returns the input of the router running the option if it is a file.
+func shellInput(ctx context.Context) io.Reader {
+	router, ok := ctx.Value(routerKey{}).(*CmdRouter)
+	if !ok {
+		return os.Stdin
+	}
+
+	if in, ok := router.in.(*os.File); ok {
+		return in
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestShellOption(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithInputOutput(strings.NewReader(""), &output),
+		WithOptions(
+			ShellOption("Greet", "echo hello from sh"),
+			ShellOption("Missing", "cmdrouter-no-such-command"),
+			ShellOption("Fail", "exit 3"),
+		),
+	)
+
+	if err := router.Invoke(t.Context(), "Greet"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "hello from sh\n") {
+		t.Errorf("command output not written to the router:\n%s", output.String())
+	}
+
+	if err := router.Invoke(t.Context(), "Missing"); !errors.Is(err, ErrCommandNotFound) {
+		t.Errorf("expected ErrCommandNotFound, got %v", err)
+	}
+
+	var exitErr *exec.ExitError
+	if err := router.Invoke(t.Context(), "Fail"); !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("expected exit code 3, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cmdrouter
+
+import "strings"
+
+// WithShortcutColumn adds a right-aligned "Key" column to the menu showing the Hotkey of each option.
+func WithShortcutColumn(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.shortcutColumn = enable
+	}
+}
+
+// hotkeyOption returns the number of the option whose hotkey matches the input (case-insensitive).
+func (c *CmdRouter) hotkeyOption(input string) (int, bool) {
+	if input == "" {
+		return 0, false
+	}
+
+	for i, option := range c.options {
+		if strings.EqualFold(option.Hotkey, input) {
+			return i + 1, true
+		}
+	}
+
+	return 0, false
+}
+
+// withShortcutColumn appends the shortcut column to the menu table.
+// The shortcuts are padded on the left to the width of the column, so they are right-aligned.
+func withShortcutColumn(headers []string, rows [][]any, shortcuts []string) ([]string, [][]any) {
+	const header = "Key"
+
+	width := displayWidth(header)
+	for _, shortcut := range shortcuts {
+		width = max(width, displayWidth(shortcut))
+	}
+
+	headers = append(headers[:len(headers):len(headers)], header)
+
+	for i, shortcut := range shortcuts {
+		padded := strings.Repeat(" ", width-displayWidth(shortcut)) + shortcut
+		rows[i] = append(rows[i][:len(rows[i]):len(rows[i])], padded)
+	}
+
+	return headers, rows
+}
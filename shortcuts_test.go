@@ -0,0 +1,46 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestShortcutColumn(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	option := func(name, hotkey string) Option {
+		return Option{Name: name, Hotkey: hotkey, Handler: func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithShortcutColumn(true),
+		WithOptions(option("Login", "l"), option("Status", ""), option("Logout", "^X")),
+		WithInputOutput(strings.NewReader("L\n^x\n0\n"), &output),
+	)
+
+	router.Run(ctx)
+
+	menu := strings.Join([]string{
+		"| # | Menu   | Key |",
+		"+---+--------+-----+",
+		"| 1 | Login  |   l |",
+		"| 2 | Status |     |",
+		"| 3 | Logout |  ^X |",
+		"| 0 | Exit   |     |",
+	}, "\n")
+	if !strings.Contains(output.String(), menu) {
+		t.Errorf("shortcut column is not rendered right-aligned:\n%s", output.String())
+	}
+
+	if strings.Join(executed, ",") != "Login,Logout" {
+		t.Errorf("hotkeys didn't select the options: %v", executed)
+	}
+}
@@ -0,0 +1,51 @@
+package cmdrouter
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortOrder defines the order in which options are shown in the menu.
+type SortOrder int
+
+const (
+	// SortNone shows options in the order they were added (default).
+	SortNone SortOrder = iota
+	// SortByWeight shows options in ascending Weight order, keeping the order they were added for equal weights.
+	SortByWeight
+	// SortByName shows options in case-insensitive alphabetical order.
+	SortByName
+)
+
+// WithSort sets the order in which options are shown in the menu of the router and its groups.
+// Options are numbered in the order they are shown.
+func WithSort(order SortOrder) Setting {
+	return func(c *CmdRouter) {
+		c.sortOrder = order
+		c.sortOptions()
+	}
+}
+
+// sortOptions orders the options by the sort order of the router and then by category
+// if auto sections are enabled. The sorting is stable, so equal options keep the order
+// in which they were added.
+func (c *CmdRouter) sortOptions() {
+	switch c.sortOrder {
+	case SortByWeight:
+		slices.SortStableFunc(c.options, func(a, b Option) int {
+			return cmp.Compare(a.Weight, b.Weight)
+		})
+	case SortByName:
+		slices.SortStableFunc(c.options, func(a, b Option) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	case SortNone:
+	}
+
+	if c.autoSections {
+		slices.SortStableFunc(c.options, func(a, b Option) int {
+			return cmp.Compare(a.Category, b.Category)
+		})
+	}
+}
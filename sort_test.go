@@ -0,0 +1,45 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSortByWeight(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	var executed []string
+
+	option := func(name string, weight int) Option {
+		return Option{Name: name, Weight: weight, Handler: func(_ context.Context) error {
+			executed = append(executed, name)
+			return nil
+		}}
+	}
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithOptions(option("Quit game", 100), option("New game", 0)),
+		WithSort(SortByWeight),
+		WithInputOutput(strings.NewReader("1\n3\n0\n"), &output),
+	)
+	router.AddOptions(option("Load game", 10), option("Continue", 0))
+
+	router.Run(ctx)
+
+	menu := strings.Join([]string{
+		"| 1 | New game  |",
+		"| 2 | Continue  |",
+		"| 3 | Load game |",
+		"| 4 | Quit game |",
+	}, "\n")
+	if !strings.Contains(output.String(), menu) {
+		t.Errorf("options are not sorted by weight:\n%s", output.String())
+	}
+
+	if strings.Join(executed, ",") != "New game,Load game" {
+		t.Errorf("selection doesn't match the shown numbers: %v", executed)
+	}
+}
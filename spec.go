@@ -0,0 +1,80 @@
+package cmdrouter
+
+import "fmt"
+
+// MenuSpec is the structure of a menu without its handlers, see Export.
+// It can be serialized, e.g. with encoding/json, to share menu layouts between programs.
+type MenuSpec struct {
+	Name    string       `json:"name"`
+	Options []OptionSpec `json:"options"`
+}
+
+// OptionSpec is a single option of a MenuSpec.
+type OptionSpec struct {
+	Name string    `json:"name"`
+	ID   string    `json:"id"`             // Stable ID of the option, see Option.ID.
+	Menu *MenuSpec `json:"menu,omitempty"` // Submenu opened by the option, nil for commands.
+}
+
+// Export returns the structure of the router and its groups: the names and IDs
+// of the options in menu order, without handlers, middlewares and settings.
+func (c *CmdRouter) Export() MenuSpec {
+	spec := MenuSpec{Name: c.name, Options: make([]OptionSpec, 0, len(c.options))}
+
+	for _, option := range c.options {
+		optionSpec := OptionSpec{Name: option.Name, ID: option.id()}
+		if option.group != nil {
+			menu := option.group.Export()
+			optionSpec.Menu = &menu
+		}
+
+		spec.Options = append(spec.Options, optionSpec)
+	}
+
+	return spec
+}
+
+// ImportInto adds the options of the spec to the router, creating its submenus as groups,
+// and binds the commands to the handlers by their IDs. The name of the spec itself is not used.
+// If a command has no handler in the map, the router is left unchanged and the error
+// wraps ErrUnknownHandler.
+func (c *CmdRouter) ImportInto(spec MenuSpec, handlers map[string]Handler) error {
+	if err := spec.checkHandlers(handlers); err != nil {
+		return err
+	}
+
+	c.importSpec(spec, handlers)
+
+	return nil
+}
+
+// checkHandlers reports the first command of the spec without a handler in the map.
+func (s MenuSpec) checkHandlers(handlers map[string]Handler) error {
+	for _, option := range s.Options {
+		if option.Menu != nil {
+			if err := option.Menu.checkHandlers(handlers); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if _, ok := handlers[option.ID]; !ok {
+			return fmt.Errorf("option %q: %w %q", option.Name, ErrUnknownHandler, option.ID)
+		}
+	}
+
+	return nil
+}
+
+// importSpec adds the options of the spec to the router.
+func (c *CmdRouter) importSpec(spec MenuSpec, handlers map[string]Handler) {
+	for _, option := range spec.Options {
+		if option.Menu != nil {
+			c.Group(option.Name).importSpec(*option.Menu, handlers)
+			continue
+		}
+
+		c.AddOptions(Option{Name: option.Name, ID: option.ID, Handler: handlers[option.ID]})
+	}
+}
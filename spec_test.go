@@ -0,0 +1,59 @@
+package cmdrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestExportImport(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+
+	source := NewCmdRouter("Tool A", Option{Name: "Status", Handler: noop})
+	source.Group("Developer").Group("Logs", Option{Name: "Backend logs", ID: "logs.backend", Handler: noop})
+
+	data, err := json.Marshal(source.Export())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spec MenuSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran []string
+
+	handlers := map[string]Handler{
+		"status": noop,
+		"logs.backend": func(ctx context.Context) error {
+			ran = append(ran, OptionID(ctx))
+			return nil
+		},
+	}
+
+	target := NewCmdRouter("Tool B")
+	if err := target.ImportInto(spec, handlers); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := target.Invoke(t.Context(), "Developer", "Logs", "Backend logs"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ran) != 1 || ran[0] != "logs.backend" {
+		t.Errorf("ran %v, want [logs.backend]", ran)
+	}
+
+	delete(handlers, "status")
+
+	empty := NewCmdRouter("Tool C")
+	if err := empty.ImportInto(spec, handlers); !errors.Is(err, ErrUnknownHandler) {
+		t.Errorf("expected ErrUnknownHandler, got %v", err)
+	}
+
+	if len(empty.options) != 0 {
+		t.Errorf("router changed by a failed import: %d options", len(empty.options))
+	}
+}
@@ -0,0 +1,48 @@
+package cmdrouter
+
+import (
+	"context"
+	"sync"
+)
+
+// Store holds state shared by the commands of a router and its groups across selections,
+// e.g. the selected project. It is safe for concurrent use.
+type Store struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+// Set stores the value under the key.
+func (s *Store) Set(key string, v any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.values == nil {
+		s.values = map[string]any{}
+	}
+	s.values[key] = v
+}
+
+// Get returns the value stored under the key.
+func (s *Store) Get(key string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Store returns the store shared by the router and its groups.
+func (c *CmdRouter) Store() *Store {
+	return c.store
+}
+
+// StoreFrom returns the store of the router the running command belongs to,
+// or nil outside of a command.
+func StoreFrom(ctx context.Context) *Store {
+	if router, ok := ctx.Value(routerKey{}).(*CmdRouter); ok {
+		return router.store
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmdrouter
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestStoreSharedByHandlers(t *testing.T) {
+	router := NewCmdRouter("Menu", Option{Name: "Select project", Handler: func(ctx context.Context) error {
+		StoreFrom(ctx).Set("project", "cmdrouter")
+		return nil
+	}})
+
+	var seen []any
+
+	var mu sync.Mutex
+
+	router.Group("Build", Option{Name: "Run", Handler: func(ctx context.Context) error {
+		project, _ := StoreFrom(ctx).Get("project")
+
+		mu.Lock()
+		seen = append(seen, project)
+		mu.Unlock()
+
+		return nil
+	}})
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			_ = router.Invoke(t.Context(), "Select project")
+		}()
+
+		go func() {
+			defer wg.Done()
+			_ = router.Invoke(t.Context(), "Build", "Run")
+		}()
+	}
+
+	wg.Wait()
+
+	_ = router.Invoke(t.Context(), "Build", "Run")
+
+	if len(seen) != 5 || seen[4] != "cmdrouter" {
+		t.Errorf("unexpected values seen by the group command: %v", seen)
+	}
+
+	if project, ok := router.Store().Get("project"); !ok || project != "cmdrouter" {
+		t.Errorf("unexpected project in the store: %v", project)
+	}
+
+	if StoreFrom(t.Context()) != nil {
+		t.Error("StoreFrom returned a store outside of a command")
+	}
+}
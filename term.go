@@ -0,0 +1,65 @@
+package cmdrouter
+
+import (
+	"io"
+	"os"
+)
+
+// isTerminal reports whether the given stream is attached to a terminal.
+func isTerminal(stream any) bool {
+	f, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth returns the width of the terminal attached to out in columns.
+// It returns 0 if out is not a terminal or the width can't be detected.
+func terminalWidth(out io.Writer) int {
+	if !isTerminal(out) {
+		return 0
+	}
+
+	width, ok := getWinsize(out.(*os.File).Fd()) //nolint:forcetypeassert // checked by isTerminal
+	if !ok {
+		return 0
+	}
+
+	return width
+}
+
+const (
+	enterAltScreen = "\x1b[?1049h" // Switches the terminal to the alternate screen buffer.
+	leaveAltScreen = "\x1b[?1049l" // Restores the main screen buffer.
+	inverseVideo   = "\x1b[7m"     // Swaps the foreground and background colors.
+	resetStyle     = "\x1b[0m"     // Resets the text style.
+)
+
+// WithAltScreen makes the root menu run on the alternate screen buffer of the terminal,
+// keeping the user's scrollback clean. It has no effect if the output is not a terminal.
+func WithAltScreen(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.altScreen = enable
+	}
+}
+
+// useAltScreen switches the terminal to the alternate screen if it is enabled
+// and returns a function restoring the main screen.
+func (c *CmdRouter) useAltScreen() (restore func()) {
+	if !c.altScreen || c.isGroup || !c.terminalFunc(c.out) {
+		return func() {}
+	}
+
+	_, _ = io.WriteString(c.out, enterAltScreen)
+
+	return func() {
+		_, _ = io.WriteString(c.out, leaveAltScreen)
+	}
+}
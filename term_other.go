@@ -0,0 +1,18 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package cmdrouter
+
+import "errors"
+
+// errNotSupported is returned by terminal operations unsupported on this platform.
+var errNotSupported = errors.New("not supported on this platform")
+
+// getWinsize is not supported on this platform.
+func getWinsize(_ uintptr) (int, bool) {
+	return 0, false
+}
+
+// enableCbreak is not supported on this platform.
+func enableCbreak(_ uintptr) (func(), error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,39 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAltScreen(t *testing.T) {
+	ctx := t.Context()
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithAltScreen(true),
+		WithInputOutput(strings.NewReader("1\n0\n0\n"), &output),
+	)
+	router.Group("Group")
+	router.terminalFunc = func(any) bool { return true }
+
+	router.Run(ctx)
+
+	if !strings.HasPrefix(output.String(), enterAltScreen) || !strings.HasSuffix(output.String(), leaveAltScreen) {
+		t.Errorf("alternate screen sequences are not emitted around the menu: %q", output.String())
+	}
+
+	if count := strings.Count(output.String(), enterAltScreen); count != 1 {
+		t.Errorf("expected to enter the alternate screen once, got %d", count)
+	}
+
+	output.Reset()
+	router.terminalFunc = func(any) bool { return false }
+	router.SetInputOutput(strings.NewReader("0\n"), &output)
+
+	router.Run(ctx)
+
+	if strings.Contains(output.String(), enterAltScreen) {
+		t.Error("alternate screen is used without a terminal")
+	}
+}
@@ -0,0 +1,62 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package cmdrouter
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors the kernel struct returned by the TIOCGWINSZ ioctl.
+type winsize struct {
+	rows    uint16
+	cols    uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+// getWinsize queries the terminal size of the given file descriptor.
+func getWinsize(fd uintptr) (int, bool) {
+	var ws winsize
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd,
+		uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.cols == 0 {
+		return 0, false
+	}
+
+	return int(ws.cols), true
+}
+
+// enableCbreak turns off line buffering and echo for the terminal, so the input
+// can be read key by key. Signals like Ctrl-C keep working.
+// The returned function restores the previous terminal state.
+func enableCbreak(fd uintptr) (func(), error) {
+	var state syscall.Termios
+	if err := ioctlTermios(fd, ioctlGetTermios, &state); err != nil {
+		return nil, err
+	}
+
+	cbreak := state
+	cbreak.Lflag &^= syscall.ECHO | syscall.ICANON
+	cbreak.Cc[syscall.VMIN] = 1
+	cbreak.Cc[syscall.VTIME] = 0
+
+	if err := ioctlTermios(fd, ioctlSetTermios, &cbreak); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_ = ioctlTermios(fd, ioctlSetTermios, &state)
+	}, nil
+}
+
+// ioctlTermios gets or sets the terminal attributes.
+func ioctlTermios(fd, request uintptr, state *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(unsafe.Pointer(state)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
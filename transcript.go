@@ -0,0 +1,27 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// Transcript runs the router with the given input lines until it exits or the input ends,
+// and returns everything written to its output, e.g. for documentation or golden tests.
+// The router and its groups use their previous streams again afterwards.
+// The error is ctx.Err() if the context is done before the router exits.
+func (c *CmdRouter) Transcript(ctx context.Context, inputs []string) (string, error) {
+	in, out, input, editor := c.in, c.out, c.input, c.editor
+
+	defer func() {
+		c.in, c.out, c.input, c.editor = in, out, input, editor
+		c.shareStreams()
+	}()
+
+	var output bytes.Buffer
+
+	c.SetTreeInputOutput(strings.NewReader(strings.Join(inputs, "\n")+"\n"), &output)
+	c.Run(ctx)
+
+	return output.String(), ctx.Err()
+}
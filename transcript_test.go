@@ -0,0 +1,39 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTranscript(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu", WithInputOutput(strings.NewReader(""), &output))
+	router.AddOptions(Option{Name: "Greet", Handler: func(_ context.Context) error {
+		_, err := fmt.Fprintln(router.out, "Hello!")
+		return err
+	}})
+
+	transcript, err := router.Transcript(t.Context(), []string{"1", "0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"| 1 | Greet |", "Hello!", "Enter option number: "} {
+		if !strings.Contains(transcript, s) {
+			t.Errorf("transcript misses %q:\n%s", s, transcript)
+		}
+	}
+
+	again, _ := router.Transcript(t.Context(), []string{"1", "0"})
+	if again != transcript {
+		t.Error("transcript is not deterministic")
+	}
+
+	if output.Len() != 0 || router.out != &output {
+		t.Error("router streams were not restored")
+	}
+}
@@ -0,0 +1,160 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrUnknownPath is returned when a path doesn't lead to a command in the menu tree.
+var ErrUnknownPath = errors.New("unknown path")
+
+// treeOption is a command found in the menu tree together with everything
+// needed to run it as if the user had navigated to it.
+type treeOption struct {
+	path   []string     // Names of the routers leading to the command and the command itself.
+	router *CmdRouter   // Router the command belongs to.
+	option Option       // The command itself.
+	chain  []Middleware // Middlewares of every level on the way to the command.
+}
+
+// handler returns the command handler composed with the middlewares of every level.
+// It runs with the router the command belongs to in the context, also when it is
+// called from another menu, e.g. from the favorites.
+func (t treeOption) handler() Handler {
+	handler := applyMiddlewares(t.option.Run, t.chain)
+
+	return func(ctx context.Context) error {
+		return handler(withRouter(ctx, t.router))
+	}
+}
+
+// fullPath returns the full path of the command, e.g. "> Main Menu > Developer > Logs".
+func (t treeOption) fullPath() string {
+	return t.router.optionPath(t.option.Name)
+}
+
+// Walk calls visit for every command in this router and its groups, depth-first,
+// in menu order. Options opening groups are not visited themselves. The path holds
+// the names of the groups leading to the command and its own name, without the name
+// of this router, so it can be passed to Exec or Invoke as is.
+func (c *CmdRouter) Walk(visit func(path []string, option Option)) {
+	c.walkTree(func(found treeOption) {
+		visit(found.path[1:], found.option)
+	})
+}
+
+// NumberedEntry is an option of the menu tree with its hierarchical number.
+type NumberedEntry struct {
+	Number string   // Dotted number reflecting the hierarchy, e.g. "2.1".
+	Path   []string // Names of the groups leading to the option and its own name.
+	Option Option   // The option itself.
+	Group  bool     // True if the option opens a group.
+}
+
+// NumberedTree returns all options of this router and its groups, depth-first,
+// numbered hierarchically like "1", "1.1", "1.2", "2", e.g. for a site map or printed manual.
+// The numbers at each level match the ones shown in the menus.
+func (c *CmdRouter) NumberedTree() []NumberedEntry {
+	return c.numberLevel("", nil, nil)
+}
+
+// numberLevel appends the numbered options of this router and its groups to entries.
+func (c *CmdRouter) numberLevel(prefix string, path []string, entries []NumberedEntry) []NumberedEntry {
+	for i, option := range c.options {
+		number := prefix + strconv.Itoa(i+1)
+		optionPath := append(path[:len(path):len(path)], option.Name)
+
+		entries = append(entries, NumberedEntry{
+			Number: number,
+			Path:   optionPath,
+			Option: option,
+			Group:  option.group != nil,
+		})
+
+		if option.group != nil {
+			entries = option.group.numberLevel(number+".", optionPath, entries)
+		}
+	}
+
+	return entries
+}
+
+// walkTree calls visit for every command in this router and its groups, depth-first.
+// The middlewares of every router on the way (and of the options opening its groups)
+// are accumulated so that a command runs with the same chain as if the user had navigated to it.
+func (c *CmdRouter) walkTree(visit func(treeOption)) {
+	c.walkLevel(nil, nil, visit)
+}
+
+// walkLevel walks the commands of this router and recurses into its groups.
+func (c *CmdRouter) walkLevel(path []string, outer []Middleware, visit func(treeOption)) {
+	path = append(path[:len(path):len(path)], c.name)
+
+	for _, opt := range c.options {
+		chain := append(outer[:len(outer):len(outer)], c.middlewaresFor(opt)...)
+
+		if opt.group != nil {
+			opt.group.walkLevel(path, append(chain, opt.middlewares...), visit)
+			continue
+		}
+
+		visit(treeOption{
+			path:   append(path[:len(path):len(path)], opt.Name),
+			router: c,
+			option: opt,
+			chain:  chain,
+		})
+	}
+}
+
+// resolve finds the command by the names of the groups leading to it and its own name.
+func (c *CmdRouter) resolve(names []string) (treeOption, error) {
+	if len(names) == 0 {
+		return treeOption{}, fmt.Errorf("%w: empty path", ErrUnknownPath)
+	}
+
+	router := c
+	path := []string{c.name}
+
+	var chain []Middleware
+
+	for i, name := range names {
+		option, ok := router.findOption(name)
+		if !ok {
+			return treeOption{}, fmt.Errorf("%w: %q not found in %q", ErrUnknownPath, name, router.name)
+		}
+
+		chain = append(chain, router.middlewaresFor(option)...)
+		path = append(path, name)
+
+		if i == len(names)-1 {
+			if option.group != nil {
+				return treeOption{}, fmt.Errorf("%w: %q is a group", ErrUnknownPath, name)
+			}
+
+			return treeOption{path: path, router: router, option: option, chain: chain}, nil
+		}
+
+		if option.group == nil {
+			return treeOption{}, fmt.Errorf("%w: %q is not a group", ErrUnknownPath, name)
+		}
+
+		chain = append(chain, option.middlewares...)
+		router = option.group
+	}
+
+	return treeOption{}, ErrUnknownPath
+}
+
+// findOption returns the first option with the given name.
+func (c *CmdRouter) findOption(name string) (Option, bool) {
+	for _, option := range c.options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return Option{}, false
+}
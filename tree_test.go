@@ -0,0 +1,56 @@
+package cmdrouter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	router := NewCmdRouter("Menu", Option{Name: "Login"})
+	developer := router.Group("Developer", Option{Name: "System Info"})
+	developer.Group("Debug Logs", Option{Name: "Backend logs"}, Option{Name: "Frontend logs"})
+	router.AddOptions(Option{Name: "Logout"})
+
+	var visited []string
+
+	router.Walk(func(path []string, option Option) {
+		if path[len(path)-1] != option.Name {
+			t.Errorf("path %v doesn't end with the option name %q", path, option.Name)
+		}
+
+		visited = append(visited, strings.Join(path, "/"))
+	})
+
+	expected := []string{
+		"Login",
+		"Developer/System Info",
+		"Developer/Debug Logs/Backend logs",
+		"Developer/Debug Logs/Frontend logs",
+		"Logout",
+	}
+	if strings.Join(visited, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, visited)
+	}
+}
+
+func TestNumberedTree(t *testing.T) {
+	router := NewCmdRouter("Menu", Option{Name: "Login"})
+	router.Group("Developer", Option{Name: "System Info"}, Option{Name: "Logs"})
+	router.AddOptions(Option{Name: "Logout"})
+
+	var numbered []string
+	for _, entry := range router.NumberedTree() {
+		numbered = append(numbered, entry.Number+" "+strings.Join(entry.Path, "/"))
+	}
+
+	expected := []string{
+		"1 Login",
+		"2 Developer",
+		"2.1 Developer/System Info",
+		"2.2 Developer/Logs",
+		"3 Logout",
+	}
+	if strings.Join(numbered, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, numbered)
+	}
+}
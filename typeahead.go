@@ -0,0 +1,43 @@
+package cmdrouter
+
+import (
+	"context"
+	"strings"
+)
+
+// WithTypeAhead makes prompts take a valid selection that is already buffered in the input
+// without showing the menu, which speeds up navigation with piped or pasted input.
+// The menu is still shown when no input is available yet or the input is not a valid
+// selection. It has no effect with line editing enabled by WithReadline.
+func WithTypeAhead(enable bool) Setting {
+	return func(c *CmdRouter) {
+		c.typeAhead = enable
+	}
+}
+
+// typeAheadSelection consumes the selection typed ahead, if type-ahead is enabled
+// and the buffered input is a valid selection. It reports false otherwise,
+// leaving the input to be read by the prompt.
+func (c *CmdRouter) typeAheadSelection(ctx context.Context) ([]int, bool) {
+	if !c.typeAhead || c.editor != nil {
+		return nil, false
+	}
+
+	line, ok := c.input.peekBuffered()
+	if !ok {
+		return nil, false
+	}
+
+	numbers, ok := c.parseSelection(strings.TrimSpace(line))
+	if !ok {
+		return nil, false
+	}
+
+	if _, err := c.input.readLine(ctx); err != nil {
+		return nil, false
+	}
+
+	c.skipRedraw = false
+
+	return numbers, true
+}
@@ -0,0 +1,40 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTypeAheadSkipsMenus(t *testing.T) {
+	runs := 0
+
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithTypeAhead(true),
+		WithInputOutput(strings.NewReader("1\n1\n0\n"), &output),
+	)
+	router.Group("Dev", Option{Name: "Logs", Handler: func(context.Context) error {
+		runs++
+		return nil
+	}})
+
+	router.Run(t.Context())
+
+	if runs != 1 {
+		t.Fatalf("Logs ran %d times, want 1", runs)
+	}
+
+	out := output.String()
+
+	if n := strings.Count(out, "| # | Dev"); n != 0 {
+		t.Errorf("Dev menu rendered %d times, want 0:\n%s", n, out)
+	}
+
+	// The root menu is rendered before any input is read and when the input has ended.
+	if n := strings.Count(out, "| # | Menu"); n != 2 {
+		t.Errorf("root menu rendered %d times, want 2:\n%s", n, out)
+	}
+}
@@ -0,0 +1,56 @@
+package cmdrouter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNilMiddleware is reported by Validate for nil middlewares, which would panic at run time.
+var ErrNilMiddleware = errors.New("nil middleware")
+
+// Validate checks the router and its groups for misconfigurations that would
+// otherwise fail only when a command runs: nil middlewares (ErrNilMiddleware)
+// and options with more than one handler set (ErrAmbiguousHandler).
+// All problems found are joined into the returned error.
+func (c *CmdRouter) Validate() error {
+	var errs []error
+
+	path := strings.TrimSpace(c.path)
+
+	if hasNil(c.middlewares) {
+		errs = append(errs, fmt.Errorf("%s: %w in router middlewares", path, ErrNilMiddleware))
+	}
+
+	if hasNil(c.navMiddlewares) {
+		errs = append(errs, fmt.Errorf("%s: %w in navigation middlewares", path, ErrNilMiddleware))
+	}
+
+	for _, scoped := range c.scoped {
+		if hasNil(scoped.middlewares) {
+			errs = append(errs, fmt.Errorf("%s: %w in scoped middlewares", path, ErrNilMiddleware))
+		}
+	}
+
+	for _, option := range c.options {
+		if hasNil(option.middlewares) {
+			errs = append(errs, fmt.Errorf("%s: %w", c.optionPath(option.Name), ErrNilMiddleware))
+		}
+
+		if option.ambiguous() {
+			errs = append(errs, fmt.Errorf("%s: %w", c.optionPath(option.Name), ErrAmbiguousHandler))
+		}
+
+		if option.group != nil {
+			errs = append(errs, option.group.Validate())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// hasNil reports whether any of the middlewares is nil.
+func hasNil[M Middleware | NavMiddleware](middlewares []M) bool {
+	return slices.ContainsFunc(middlewares, func(m M) bool { return m == nil })
+}
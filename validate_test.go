@@ -0,0 +1,44 @@
+package cmdrouter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateNilMiddleware(t *testing.T) {
+	router := NewCmdRouterWithSettings("Menu", WithMiddlewares(DefaultRecoverMiddleware))
+
+	if err := router.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	option := Option{Name: "Login", Handler: func(_ context.Context) error { return nil }}
+	option.AddMiddlewares(nil)
+	router.Group("Auth", option)
+
+	err := router.Validate()
+	if !errors.Is(err, ErrNilMiddleware) {
+		t.Fatalf("expected ErrNilMiddleware, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "> Menu > Auth > Login") {
+		t.Errorf("error doesn't point to the option: %v", err)
+	}
+}
+
+func TestValidateNilNavMiddleware(t *testing.T) {
+	router := NewCmdRouter("Menu")
+	router.AddNavMiddleware(nil)
+	router.Group("Settings")
+
+	err := router.Validate()
+	if !errors.Is(err, ErrNilMiddleware) {
+		t.Fatalf("expected ErrNilMiddleware, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "navigation middlewares") {
+		t.Errorf("error doesn't point to the navigation middlewares: %v", err)
+	}
+}
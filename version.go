@@ -0,0 +1,32 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// defaultVersionName is the name of the option added by WithVersionOption if none is given.
+const defaultVersionName = "Version"
+
+// WithVersionOption adds an option printing the version of the application,
+// followed by the Go version it was built with if the build info is available.
+// The option is named "Version" if name is empty, and is added after the options
+// added so far, so the settings order controls its position in the menu.
+func WithVersionOption(name, version string) Setting {
+	if name == "" {
+		name = defaultVersionName
+	}
+
+	return WithOptions(Option{Name: name, Handler: func(ctx context.Context) error {
+		out := Out(ctx)
+
+		_, _ = fmt.Fprintln(out, version)
+
+		if info, ok := debug.ReadBuildInfo(); ok {
+			_, _ = fmt.Fprintf(out, "Built with %s\n", info.GoVersion)
+		}
+
+		return nil
+	}})
+}
@@ -0,0 +1,26 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVersionOption(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithVersionOption("", "v1.2.3"),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+	)
+
+	router.Run(t.Context())
+
+	if !strings.Contains(output.String(), "| 1 | Version |") {
+		t.Errorf("version option not shown:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), "\nv1.2.3\n") {
+		t.Errorf("version not printed:\n%s", output.String())
+	}
+}
@@ -0,0 +1,68 @@
+package cmdrouter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// WithSlowHandlerWarning prints "(still running…)" once if a command runs longer than d,
+// to reassure users during long operations. The command isn't interrupted. Zero disables it.
+func WithSlowHandlerWarning(d time.Duration) Setting {
+	return func(c *CmdRouter) {
+		c.slowWarning = d
+	}
+}
+
+// watched wraps the command handler so that a command running longer than the slow
+// warning threshold is reported while it runs, if it is enabled. The warning is written
+// to Out(ctx) of the handler, and the writes of both are serialized.
+func (c *CmdRouter) watched(handler Handler) Handler {
+	if c.slowWarning <= 0 {
+		return handler
+	}
+
+	return func(ctx context.Context) error {
+		out := &lockedWriter{w: Out(ctx)}
+		ctx = context.WithValue(ctx, outKey{}, out)
+
+		stop := make(chan struct{})
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			timer := time.NewTimer(c.slowWarning)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				_, _ = fmt.Fprintln(out, "(still running…)")
+			case <-stop:
+			}
+		}()
+
+		err := handler(ctx)
+
+		close(stop)
+		<-done
+
+		return err
+	}
+}
+
+// lockedWriter serializes the writes to the underlying writer.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write implements the io.Writer interface.
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
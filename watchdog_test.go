@@ -0,0 +1,60 @@
+package cmdrouter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlowHandlerWarning(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithSlowHandlerWarning(10*time.Millisecond),
+		WithInputOutput(strings.NewReader("1\n2\n0\n"), &output),
+		WithOptions(
+			Option{Name: "Build", Handler: func(context.Context) error {
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			}},
+			Option{Name: "Status", Handler: func(context.Context) error {
+				return nil
+			}},
+		),
+	)
+
+	router.Run(t.Context())
+
+	if n := strings.Count(output.String(), "(still running…)"); n != 1 {
+		t.Errorf("warning printed %d times, want once:\n%s", n, output.String())
+	}
+}
+
+func TestSlowHandlerWarningOutput(t *testing.T) {
+	var output bytes.Buffer
+
+	router := NewCmdRouterWithSettings("Menu",
+		WithSlowHandlerWarning(5*time.Millisecond),
+		WithInputOutput(strings.NewReader("1\n0\n"), &output),
+		WithOptions(Option{Name: "Build", Handler: func(ctx context.Context) error {
+			for range 20 {
+				_, _ = fmt.Fprintln(Out(ctx), "compiling")
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		}}),
+	)
+
+	router.Run(t.Context())
+
+	if n := strings.Count(output.String(), "compiling\n"); n != 20 {
+		t.Errorf("handler output written %d times, want 20:\n%s", n, output.String())
+	}
+
+	if n := strings.Count(output.String(), "(still running…)\n"); n != 1 {
+		t.Errorf("warning printed %d times, want once:\n%s", n, output.String())
+	}
+}